@@ -0,0 +1,143 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Barrier is a reusable cyclic barrier, it lets a fixed number of goroutines wait for each other,
+// all of them are released when the last one arrives, then the barrier is reset for the next round.
+type Barrier struct {
+	parties int
+	mu      sync.Mutex
+	count   int
+	trip    chan struct{}
+}
+
+// NewBarrier creates a Barrier for the given number of parties.
+func NewBarrier(parties int) *Barrier {
+	if parties <= 0 {
+		panic("NewBarrier: parties should be positive")
+	}
+
+	return &Barrier{parties: parties, trip: make(chan struct{})}
+}
+
+// Await blocks until all parties have called Await or ctx is done. If ctx is done first,
+// the caller leaves the barrier and Await returns ctx.Err(), the other parties keep waiting.
+func (b *Barrier) Await(ctx context.Context) error {
+	b.mu.Lock()
+	trip := b.trip
+	b.count++
+	if b.count == b.parties {
+		close(trip)
+		b.count = 0
+		b.trip = make(chan struct{})
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-trip:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if b.trip != trip {
+			// the barrier tripped while ctx was being done
+			return nil
+		}
+		b.count--
+		return ctx.Err()
+	}
+}
+
+// Parties returns the number of parties required to trip the barrier.
+func (b *Barrier) Parties() int {
+	return b.parties
+}
+
+// Waiting returns the number of parties currently waiting at the barrier.
+func (b *Barrier) Waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.count
+}
+
+// CountDownLatch lets goroutines wait until a set of operations being performed in other goroutines completes.
+// It is not reusable, once the count reaches zero all waiters are released and later waits return at once.
+type CountDownLatch struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{}
+}
+
+// NewCountDownLatch creates a CountDownLatch with the given count.
+func NewCountDownLatch(count int) *CountDownLatch {
+	if count < 0 {
+		panic("NewCountDownLatch: count should not be negative")
+	}
+
+	latch := &CountDownLatch{count: count, done: make(chan struct{})}
+	if count == 0 {
+		close(latch.done)
+	}
+
+	return latch
+}
+
+// CountDown decrements the count, the waiters are released when the count reaches zero.
+func (l *CountDownLatch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count == 0 {
+		return
+	}
+
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the current count.
+func (l *CountDownLatch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.count
+}
+
+// Wait blocks until the count reaches zero.
+func (l *CountDownLatch) Wait() {
+	<-l.done
+}
+
+// WaitTimeout blocks until the count reaches zero or timeout has passed, it reports whether the count reached zero.
+func (l *CountDownLatch) WaitTimeout(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-l.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// WaitContext blocks until the count reaches zero or ctx is done.
+func (l *CountDownLatch) WaitContext(ctx context.Context) error {
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestBarrier(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBarrier")
+
+	barrier := NewBarrier(3)
+	assert.Equal(3, barrier.Parties())
+
+	var arrived int32
+	var wg sync.WaitGroup
+
+	// the barrier is reused for several rounds
+	for round := 1; round <= 3; round++ {
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				atomic.AddInt32(&arrived, 1)
+				assert.IsNil(barrier.Await(context.Background()))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(int32(round*3), atomic.LoadInt32(&arrived))
+		assert.Equal(0, barrier.Waiting())
+	}
+}
+
+func TestBarrier_Cancel(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBarrier_Cancel")
+
+	barrier := NewBarrier(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, barrier.Await(ctx))
+	assert.Equal(0, barrier.Waiting())
+
+	done := make(chan error)
+	go func() {
+		done <- barrier.Await(context.Background())
+	}()
+	assert.IsNil(barrier.Await(context.Background()))
+	assert.IsNil(<-done)
+}
+
+func TestCountDownLatch(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCountDownLatch")
+
+	latch := NewCountDownLatch(3)
+	assert.Equal(false, latch.WaitTimeout(10*time.Millisecond))
+
+	for i := 0; i < 3; i++ {
+		go latch.CountDown()
+	}
+
+	latch.Wait()
+	assert.Equal(0, latch.Count())
+	assert.Equal(true, latch.WaitTimeout(time.Millisecond))
+	assert.IsNil(latch.WaitContext(context.Background()))
+
+	// counting down a released latch has no effect
+	latch.CountDown()
+	assert.Equal(0, latch.Count())
+
+	latch = NewCountDownLatch(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(context.Canceled, latch.WaitContext(ctx))
+}
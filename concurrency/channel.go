@@ -7,6 +7,7 @@ package concurrency
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // Channel is a logic object which can generate or manipulate go channel
@@ -245,3 +246,399 @@ func (c *Channel[T]) OrDone(ctx context.Context, channel <-chan T) <-chan T {
 
 	return valStream
 }
+
+// FanOut distributes values of a channel to n channels, every value is received by only one of them,
+// the channel which is ready first takes the value.
+func (c *Channel[T]) FanOut(ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]<-chan T, n)
+
+	for i := 0; i < n; i++ {
+		out := make(chan T)
+		outs[i] = out
+
+		go func() {
+			defer close(out)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	return outs
+}
+
+// Broadcast sends every value of a channel to all of n channels, a slow receiver blocks the others.
+func (c *Channel[T]) Broadcast(ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+
+		for val := range c.OrDone(ctx, in) {
+			for _, out := range outs {
+				select {
+				case out <- val:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return result
+}
+
+// Filter create a channel whose values are the values of another channel which satisfy the predicate.
+func (c *Channel[T]) Filter(ctx context.Context, in <-chan T, predicate func(item T) bool) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for val := range c.OrDone(ctx, in) {
+			if !predicate(val) {
+				continue
+			}
+			select {
+			case out <- val:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Buffer create a channel with buffer of size, which receives values of another channel,
+// so a slow receiver does not block the sender until the buffer is full.
+func (c *Channel[T]) Buffer(ctx context.Context, in <-chan T, size int) <-chan T {
+	out := make(chan T, size)
+
+	go func() {
+		defer close(out)
+
+		for val := range c.OrDone(ctx, in) {
+			select {
+			case out <- val:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Batch groups values of a channel into slices, a batch is sent when it has size values,
+// or when maxWait has passed since its first value was received. The last partial batch is sent when the input is closed.
+func (c *Channel[T]) Batch(ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size <= 0 {
+		panic("Batch: size should be positive")
+	}
+
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timeout <-chan time.Time
+		var timer *time.Timer
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+
+			select {
+			case out <- batch:
+				batch = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) >= size && !flush() {
+					return
+				}
+			case <-timeout:
+				timer, timeout = nil, nil
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// Throttle create a channel which receives values of another channel at a rate of at most rate values per interval,
+// values are spaced evenly, the first value is sent without delay.
+func (c *Channel[T]) Throttle(ctx context.Context, in <-chan T, rate int, interval time.Duration) <-chan T {
+	if rate <= 0 {
+		panic("Throttle: rate should be positive")
+	}
+
+	out := make(chan T)
+	gap := interval / time.Duration(rate)
+
+	go func() {
+		defer close(out)
+
+		var next time.Time
+		for val := range c.OrDone(ctx, in) {
+			if wait := time.Until(next); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				}
+			}
+
+			select {
+			case out <- val:
+				next = time.Now().Add(gap)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Merge merges multiple channels into one channel with priority, when several channels have values ready,
+// the value of the channel which comes first in channels is sent first. The channel is closed when all channels are closed.
+func (c *Channel[T]) Merge(ctx context.Context, channels ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	slots := make([]chan T, len(channels))
+	notify := make(chan struct{}, 1)
+	allDone := make(chan struct{})
+
+	signal := func() {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(channels))
+
+	for i, ch := range channels {
+		slots[i] = make(chan T, 1)
+
+		go func(ch <-chan T, slot chan T) {
+			defer wg.Done()
+
+			for val := range c.OrDone(ctx, ch) {
+				select {
+				case slot <- val:
+					signal()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch, slots[i])
+	}
+
+	go func() {
+		wg.Wait()
+		close(allDone)
+	}()
+
+	go func() {
+		defer close(out)
+
+		// pending keeps the values taken from slots, the one with highest priority is offered to out,
+		// the offer is renewed whenever a new value arrives.
+		pending := make([]T, len(slots))
+		hasPending := make([]bool, len(slots))
+		finished := false
+
+		for {
+			first := -1
+			for i, slot := range slots {
+				if !hasPending[i] {
+					select {
+					case pending[i] = <-slot:
+						hasPending[i] = true
+					default:
+					}
+				}
+				if hasPending[i] && first < 0 {
+					first = i
+				}
+			}
+
+			if first < 0 {
+				if finished {
+					return
+				}
+				select {
+				case <-notify:
+				case <-allDone:
+					finished = true
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+
+			select {
+			case out <- pending[first]:
+				var zeroValue T
+				pending[first] = zeroValue
+				hasPending[first] = false
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// ParallelMap create a channel whose values are the results of fn over values of another channel,
+// fn is called by workers goroutines at the same time, the results keep the order of the input.
+func ParallelMap[T any, U any](ctx context.Context, in <-chan T, workers int, fn func(item T) U) <-chan U {
+	if workers <= 0 {
+		panic("ParallelMap: workers should be positive")
+	}
+
+	type job struct {
+		val    T
+		result chan U
+	}
+
+	out := make(chan U)
+	jobs := make(chan job, workers)
+	order := make(chan chan U, workers)
+
+	go func() {
+		defer close(jobs)
+		defer close(order)
+
+		for val := range NewChannel[T]().OrDone(ctx, in) {
+			result := make(chan U, 1)
+			select {
+			case order <- result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{val: val, result: result}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.result <- fn(j.val)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+
+		for result := range order {
+			select {
+			case val := <-result:
+				select {
+				case out <- val:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// ParallelMapUnordered is like ParallelMap, but the results are sent as soon as they are ready, regardless of the input order.
+func ParallelMapUnordered[T any, U any](ctx context.Context, in <-chan T, workers int, fn func(item T) U) <-chan U {
+	if workers <= 0 {
+		panic("ParallelMapUnordered: workers should be positive")
+	}
+
+	out := make(chan U)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for val := range NewChannel[T]().OrDone(ctx, in) {
+				select {
+				case out <- fn(val):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -194,3 +194,55 @@ func ExampleChannel_Bridge() {
 	// 4
 	// 5
 }
+
+func ExampleChannel_Batch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	batches := c.Batch(ctx, c.Generate(ctx, 1, 2, 3, 4, 5), 2, time.Second)
+
+	for batch := range batches {
+		fmt.Println(batch)
+	}
+
+	// Output:
+	// [1 2]
+	// [3 4]
+	// [5]
+}
+
+func ExampleChannel_Filter() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	evens := c.Filter(ctx, c.Generate(ctx, 1, 2, 3, 4), func(n int) bool { return n%2 == 0 })
+
+	for v := range evens {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 2
+	// 4
+}
+
+func ExampleParallelMap() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	squares := ParallelMap(ctx, c.Generate(ctx, 1, 2, 3), 2, func(n int) int {
+		return n * n
+	})
+
+	for v := range squares {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 1
+	// 4
+	// 9
+}
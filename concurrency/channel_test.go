@@ -2,6 +2,7 @@ package concurrency
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -186,3 +187,218 @@ func TestBridge(t *testing.T) {
 		index++
 	}
 }
+
+func TestFanOut(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFanOut")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	outs := c.FanOut(ctx, c.Generate(ctx, 1, 2, 3, 4, 5, 6), 3)
+	assert.Equal(3, len(outs))
+
+	result := []int{}
+	for v := range c.FanIn(ctx, outs...) {
+		result = append(result, v)
+	}
+	sort.Ints(result)
+
+	assert.Equal([]int{1, 2, 3, 4, 5, 6}, result)
+}
+
+func TestBroadcast(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBroadcast")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	outs := c.Broadcast(ctx, c.Generate(ctx, 1, 2, 3), 3)
+
+	results := make([][]int, 3)
+	done := make(chan struct{})
+	for i, out := range outs {
+		go func(i int, out <-chan int) {
+			for v := range out {
+				results[i] = append(results[i], v)
+			}
+			done <- struct{}{}
+		}(i, out)
+	}
+	for range outs {
+		<-done
+	}
+
+	for _, result := range results {
+		assert.Equal([]int{1, 2, 3}, result)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFilter")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	result := []int{}
+	for v := range c.Filter(ctx, c.Generate(ctx, 1, 2, 3, 4, 5), func(n int) bool { return n%2 == 1 }) {
+		result = append(result, v)
+	}
+
+	assert.Equal([]int{1, 3, 5}, result)
+}
+
+func TestBuffer(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBuffer")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	out := c.Buffer(ctx, c.Generate(ctx, 1, 2, 3), 3)
+
+	// the buffer is filled without any receiver
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(3, len(out))
+
+	assert.Equal(1, <-out)
+	assert.Equal(2, <-out)
+	assert.Equal(3, <-out)
+}
+
+func TestBatch(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBatch")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	result := [][]int{}
+	for batch := range c.Batch(ctx, c.Generate(ctx, 1, 2, 3, 4, 5), 2, time.Second) {
+		result = append(result, batch)
+	}
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, result)
+
+	// flush on timeout
+	in := make(chan int)
+	batches := c.Batch(ctx, in, 10, 20*time.Millisecond)
+	in <- 1
+	in <- 2
+	assert.Equal([]int{1, 2}, <-batches)
+
+	in <- 3
+	close(in)
+	assert.Equal([]int{3}, <-batches)
+
+	_, ok := <-batches
+	assert.Equal(false, ok)
+}
+
+func TestThrottle(t *testing.T) {
+	assert := internal.NewAssert(t, "TestThrottle")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+
+	start := time.Now()
+	result := []int{}
+	for v := range c.Throttle(ctx, c.Generate(ctx, 1, 2, 3, 4), 100, time.Second) {
+		result = append(result, v)
+	}
+
+	assert.Equal([]int{1, 2, 3, 4}, result)
+	assert.Equal(true, time.Since(start) >= 30*time.Millisecond)
+}
+
+func TestMerge(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMerge")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+
+	high := make(chan int, 3)
+	low := make(chan int, 3)
+	for i := 1; i <= 3; i++ {
+		low <- i * 10
+		high <- i
+	}
+	close(high)
+	close(low)
+
+	// wait until both channels have values ready before reading
+	merged := c.Merge(ctx, high, low)
+	time.Sleep(20 * time.Millisecond)
+
+	result := []int{}
+	for v := range merged {
+		result = append(result, v)
+	}
+
+	assert.Equal(6, len(result))
+	assert.Equal(1, result[0])
+
+	sorted := append([]int{}, result...)
+	sort.Ints(sorted)
+	assert.Equal([]int{1, 2, 3, 10, 20, 30}, sorted)
+}
+
+func TestParallelMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParallelMap")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	out := ParallelMap(ctx, c.Generate(ctx, 5, 4, 3, 2, 1), 3, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * n
+	})
+
+	result := []int{}
+	for v := range out {
+		result = append(result, v)
+	}
+
+	assert.Equal([]int{25, 16, 9, 4, 1}, result)
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParallelMapUnordered")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewChannel[int]()
+	out := ParallelMapUnordered(ctx, c.Generate(ctx, 1, 2, 3, 4), 2, func(n int) int {
+		return n * 2
+	})
+
+	result := []int{}
+	for v := range out {
+		result = append(result, v)
+	}
+	sort.Ints(result)
+
+	assert.Equal([]int{2, 4, 6, 8}, result)
+}
+
+func TestParallelMap_Cancel(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParallelMap_Cancel")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := NewChannel[int]()
+	out := ParallelMap(ctx, c.Repeat(ctx, 1), 2, func(n int) int { return n })
+
+	assert.Equal(1, <-out)
+	cancel()
+
+	for range out {
+	}
+}
@@ -0,0 +1,372 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrEventBusClosed is returned when publishing to or subscribing a closed event bus.
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+// SlowConsumerPolicy decides what the event bus does when the buffer of a subscriber is full.
+type SlowConsumerPolicy int
+
+const (
+	// BlockOnFull makes the publisher wait until the subscriber has room for the event.
+	BlockOnFull SlowConsumerPolicy = iota
+	// DropOldestOnFull discards the oldest buffered event to make room for the new one.
+	DropOldestOnFull
+	// DropNewestOnFull discards the new event.
+	DropNewestOnFull
+	// DisconnectOnFull closes the subscription.
+	DisconnectOnFull
+)
+
+// Event is a message published to a topic of an EventBus.
+type Event[T any] struct {
+	Topic   string
+	Payload T
+}
+
+// EventBusOption is for adding event bus config.
+type EventBusOption func(*eventBusConfig)
+
+type eventBusConfig struct {
+	queueSize int
+}
+
+// EventBusQueueSize set the capacity of the queue of PublishAsync, PublishAsync blocks while the queue is full.
+func EventBusQueueSize(n int) EventBusOption {
+	return func(ec *eventBusConfig) {
+		ec.queueSize = n
+	}
+}
+
+// EventBus is an in-memory publish/subscribe event bus. Topics are dot separated, eg. orders.created,
+// a subscription pattern may use `*` to match exactly one segment and a trailing `**` to match the remaining segments.
+// Every subscriber has its own buffered channel, and a SlowConsumerPolicy which applies when the buffer is full.
+type EventBus[T any] struct {
+	mu     sync.RWMutex
+	subs   []*Subscription[T]
+	closed bool
+
+	queue      chan Event[T]
+	quit       chan struct{}
+	publishers sync.WaitGroup
+	dispatched chan struct{}
+}
+
+// NewEventBus creates an EventBus and starts the goroutine which delivers the events of PublishAsync.
+// The goroutine exits when the bus is closed.
+func NewEventBus[T any](opts ...EventBusOption) *EventBus[T] {
+	config := eventBusConfig{queueSize: 64}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	b := &EventBus[T]{
+		queue:      make(chan Event[T], config.queueSize),
+		quit:       make(chan struct{}),
+		dispatched: make(chan struct{}),
+	}
+
+	go b.dispatch()
+
+	return b
+}
+
+// Subscribe subscribes the topics matching pattern, the events are buffered in a channel of bufferSize,
+// policy decides what happens when the buffer is full.
+func (b *EventBus[T]) Subscribe(pattern string, bufferSize int, policy SlowConsumerPolicy) (*Subscription[T], error) {
+	if pattern == "" {
+		return nil, errors.New("subscription pattern should not be empty")
+	}
+
+	sub := &Subscription[T]{
+		bus:     b,
+		pattern: pattern,
+		parts:   strings.Split(pattern, "."),
+		policy:  policy,
+		ch:      make(chan Event[T], bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrEventBusClosed
+	}
+	b.subs = append(b.subs, sub)
+
+	return sub, nil
+}
+
+// SubscribeFunc is like Subscribe, but calls handler for every event in a goroutine owned by the subscription,
+// the goroutine exits after the subscription is closed and its buffered events are handled.
+func (b *EventBus[T]) SubscribeFunc(pattern string, bufferSize int, policy SlowConsumerPolicy, handler func(event Event[T])) (*Subscription[T], error) {
+	sub, err := b.Subscribe(pattern, bufferSize, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for event := range sub.ch {
+			handler(event)
+		}
+	}()
+
+	return sub, nil
+}
+
+// Publish delivers the event to all matching subscribers before it returns, following their slow consumer policies.
+func (b *EventBus[T]) Publish(topic string, payload T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrEventBusClosed
+	}
+	b.mu.RUnlock()
+
+	b.deliver(Event[T]{Topic: topic, Payload: payload})
+
+	return nil
+}
+
+// PublishAsync puts the event into the queue of the bus and returns, the event is delivered by the bus goroutine
+// in publishing order. It blocks while the queue is full.
+func (b *EventBus[T]) PublishAsync(topic string, payload T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrEventBusClosed
+	}
+	b.publishers.Add(1)
+	b.mu.RUnlock()
+
+	defer b.publishers.Done()
+
+	select {
+	case b.queue <- Event[T]{Topic: topic, Payload: payload}:
+		return nil
+	case <-b.quit:
+		return ErrEventBusClosed
+	}
+}
+
+// Close stops accepting events, waits until the queued events of PublishAsync are delivered or ctx is done,
+// then closes all subscriptions. It returns ctx.Err() if the queue is not drained in time.
+func (b *EventBus[T]) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		<-b.dispatched
+		return nil
+	}
+	b.closed = true
+	close(b.quit)
+	b.mu.Unlock()
+
+	go func() {
+		// no one can send to queue after the blocked publishers are released
+		b.publishers.Wait()
+		close(b.queue)
+	}()
+
+	var err error
+	select {
+	case <-b.dispatched:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = nil
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+
+	// closing the subscriptions releases the bus goroutine if it is blocked by a subscriber
+	<-b.dispatched
+
+	return err
+}
+
+// Subscribers returns the number of open subscriptions.
+func (b *EventBus[T]) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs)
+}
+
+func (b *EventBus[T]) dispatch() {
+	defer close(b.dispatched)
+
+	for event := range b.queue {
+		b.deliver(event)
+	}
+}
+
+func (b *EventBus[T]) deliver(event Event[T]) {
+	topic := strings.Split(event.Topic, ".")
+
+	b.mu.RLock()
+	matched := make([]*Subscription[T], 0, len(b.subs))
+	for _, sub := range b.subs {
+		if matchTopic(sub.parts, topic) {
+			matched = append(matched, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range matched {
+		if !sub.send(event) {
+			b.remove(sub)
+		}
+	}
+}
+
+func (b *EventBus[T]) remove(sub *Subscription[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// matchTopic checks if the topic segments match the pattern segments.
+func matchTopic(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == "**" && i == len(pattern)-1 {
+			return true
+		}
+		if i >= len(topic) || (p != "*" && p != topic[i]) {
+			return false
+		}
+	}
+
+	return len(pattern) == len(topic)
+}
+
+// Subscription is a subscription of an EventBus.
+type Subscription[T any] struct {
+	bus     *EventBus[T]
+	pattern string
+	parts   []string
+	policy  SlowConsumerPolicy
+	dropped uint64
+
+	mu     sync.Mutex
+	ch     chan Event[T]
+	closed bool
+	done   chan struct{}
+	once   sync.Once
+}
+
+// C returns the channel which receives the events, it is closed when the subscription is closed.
+func (s *Subscription[T]) C() <-chan Event[T] {
+	return s.ch
+}
+
+// Done returns a channel which is closed when the subscription is closed by Unsubscribe,
+// by the DisconnectOnFull policy or by closing the bus.
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// Pattern returns the topic pattern of the subscription.
+func (s *Subscription[T]) Pattern() string {
+	return s.pattern
+}
+
+// Dropped returns the number of events discarded by the slow consumer policy.
+func (s *Subscription[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Unsubscribe removes the subscription from the bus and closes its channel, the buffered events can still be received.
+func (s *Subscription[T]) Unsubscribe() {
+	s.bus.remove(s)
+	s.close()
+}
+
+func (s *Subscription[T]) close() {
+	// release the publisher blocked by this subscriber before taking the lock
+	s.once.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// send delivers the event following the slow consumer policy, it returns false if the subscription should be removed.
+func (s *Subscription[T]) send(event Event[T]) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	switch s.policy {
+	case DropOldestOnFull:
+		for {
+			select {
+			case s.ch <- event:
+				return true
+			default:
+			}
+			if cap(s.ch) == 0 {
+				// nothing is buffered to drop for an unbuffered subscriber
+				atomic.AddUint64(&s.dropped, 1)
+				return true
+			}
+			select {
+			case <-s.ch:
+				atomic.AddUint64(&s.dropped, 1)
+			default:
+			}
+		}
+	case DropNewestOnFull:
+		select {
+		case s.ch <- event:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+		return true
+	case DisconnectOnFull:
+		select {
+		case s.ch <- event:
+			return true
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+			s.once.Do(func() { close(s.done) })
+			s.closed = true
+			close(s.ch)
+			return false
+		}
+	default:
+		select {
+		case s.ch <- event:
+			return true
+		case <-s.done:
+			return false
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+)
+
+func ExampleEventBus() {
+	bus := NewEventBus[string]()
+	defer bus.Close(context.Background())
+
+	sub, _ := bus.Subscribe("orders.*", 10, DropOldestOnFull)
+
+	bus.Publish("orders.created", "order 1")
+	bus.Publish("users.created", "user 1")
+	bus.Publish("orders.paid", "order 1")
+
+	sub.Unsubscribe()
+
+	for event := range sub.C() {
+		fmt.Println(event.Topic, event.Payload)
+	}
+
+	// Output:
+	// orders.created order 1
+	// orders.paid order 1
+}
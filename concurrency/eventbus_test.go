@@ -0,0 +1,176 @@
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestEventBus_Publish(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEventBus_Publish")
+
+	bus := NewEventBus[int]()
+	defer bus.Close(context.Background())
+
+	created, _ := bus.Subscribe("orders.created", 10, BlockOnFull)
+	orders, _ := bus.Subscribe("orders.*", 10, BlockOnFull)
+	all, _ := bus.Subscribe("**", 10, BlockOnFull)
+	assert.Equal(3, bus.Subscribers())
+
+	assert.IsNil(bus.Publish("orders.created", 1))
+	assert.IsNil(bus.Publish("orders.paid", 2))
+	assert.IsNil(bus.Publish("users.created", 3))
+	assert.IsNil(bus.Publish("orders.created.v2", 4))
+
+	assert.Equal(Event[int]{Topic: "orders.created", Payload: 1}, <-created.C())
+	assert.Equal(0, len(created.C()))
+
+	assert.Equal(1, (<-orders.C()).Payload)
+	assert.Equal(2, (<-orders.C()).Payload)
+	assert.Equal(0, len(orders.C()))
+
+	assert.Equal(4, len(all.C()))
+}
+
+func TestMatchTopic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMatchTopic")
+
+	split := func(s string) []string {
+		return strings.Split(s, ".")
+	}
+
+	assert.Equal(true, matchTopic(split("a.b"), split("a.b")))
+	assert.Equal(false, matchTopic(split("a.b"), split("a.c")))
+	assert.Equal(true, matchTopic(split("a.*"), split("a.c")))
+	assert.Equal(false, matchTopic(split("a.*"), split("a")))
+	assert.Equal(false, matchTopic(split("a.*"), split("a.b.c")))
+	assert.Equal(true, matchTopic(split("*.b"), split("a.b")))
+	assert.Equal(true, matchTopic(split("a.**"), split("a.b.c")))
+	assert.Equal(true, matchTopic(split("a.**"), split("a")))
+	assert.Equal(false, matchTopic(split("a.**"), split("b.c")))
+}
+
+func TestEventBus_SlowConsumerPolicy(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEventBus_SlowConsumerPolicy")
+
+	bus := NewEventBus[int]()
+	defer bus.Close(context.Background())
+
+	oldest, _ := bus.Subscribe("t", 2, DropOldestOnFull)
+	newest, _ := bus.Subscribe("t", 2, DropNewestOnFull)
+	disconnect, _ := bus.Subscribe("t", 2, DisconnectOnFull)
+
+	for i := 1; i <= 4; i++ {
+		bus.Publish("t", i)
+	}
+
+	assert.Equal(3, (<-oldest.C()).Payload)
+	assert.Equal(4, (<-oldest.C()).Payload)
+	assert.Equal(uint64(2), oldest.Dropped())
+
+	assert.Equal(1, (<-newest.C()).Payload)
+	assert.Equal(2, (<-newest.C()).Payload)
+	assert.Equal(uint64(2), newest.Dropped())
+
+	<-disconnect.Done()
+	assert.Equal(1, (<-disconnect.C()).Payload)
+	assert.Equal(2, (<-disconnect.C()).Payload)
+	_, ok := <-disconnect.C()
+	assert.Equal(false, ok)
+	assert.Equal(2, bus.Subscribers())
+}
+
+func TestEventBus_BlockAndUnsubscribe(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEventBus_BlockAndUnsubscribe")
+
+	bus := NewEventBus[int]()
+	defer bus.Close(context.Background())
+
+	sub, _ := bus.Subscribe("t", 0, BlockOnFull)
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish("t", 1)
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publish should block until the subscriber receives")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// unsubscribing releases the blocked publisher
+	sub.Unsubscribe()
+	<-published
+	assert.Equal(0, bus.Subscribers())
+
+	_, ok := <-sub.C()
+	assert.Equal(false, ok)
+}
+
+func TestEventBus_PublishAsync(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEventBus_PublishAsync")
+
+	bus := NewEventBus[string](EventBusQueueSize(10))
+
+	var mu sync.Mutex
+	received := []string{}
+	done := make(chan struct{})
+	bus.SubscribeFunc("greet.*", 10, BlockOnFull, func(event Event[string]) {
+		mu.Lock()
+		received = append(received, event.Payload)
+		mu.Unlock()
+		if event.Payload == "c" {
+			close(done)
+		}
+	})
+
+	assert.IsNil(bus.PublishAsync("greet.a", "a"))
+	assert.IsNil(bus.PublishAsync("greet.b", "b"))
+	assert.IsNil(bus.PublishAsync("greet.c", "c"))
+	<-done
+
+	assert.IsNil(bus.Close(context.Background()))
+
+	mu.Lock()
+	assert.Equal([]string{"a", "b", "c"}, received)
+	mu.Unlock()
+
+	assert.Equal(ErrEventBusClosed, bus.Publish("greet.d", "d"))
+	assert.Equal(ErrEventBusClosed, bus.PublishAsync("greet.d", "d"))
+	_, err := bus.Subscribe("greet.*", 1, BlockOnFull)
+	assert.Equal(ErrEventBusClosed, err)
+}
+
+func TestEventBus_CloseNoLeak(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEventBus_CloseNoLeak")
+
+	before := runtime.NumGoroutine()
+
+	bus := NewEventBus[int](EventBusQueueSize(1))
+	sub, _ := bus.Subscribe("t", 0, BlockOnFull)
+	bus.SubscribeFunc("t", 0, BlockOnFull, func(event Event[int]) {})
+
+	// the bus goroutine blocks on the subscriber which never receives
+	bus.PublishAsync("t", 1)
+	bus.PublishAsync("t", 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, bus.Close(ctx))
+
+	<-sub.Done()
+	assert.Equal(0, bus.Subscribers())
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(true, runtime.NumGoroutine() <= before)
+}
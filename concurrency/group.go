@@ -0,0 +1,158 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"context"
+	"sync"
+
+	"github.com/serialt/lancet/internal"
+)
+
+// Group runs a collection of tasks in goroutines, waits for them and collects their results in the order the tasks are added.
+// By default the first error cancels the context passed to the other tasks, and Wait returns that error.
+// A panic in a task is recovered and reported as a *PanicError.
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wg         sync.WaitGroup
+	sem        chan struct{}
+	collectAll bool
+
+	mu      sync.Mutex
+	results []T
+	errs    []error
+	err     error
+}
+
+// NewGroup creates a Group, the context passed to its tasks is derived from ctx.
+func NewGroup[T any](ctx context.Context) *Group[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group[T]{ctx: ctx, cancel: cancel}
+}
+
+// SetLimit limits the number of tasks running at the same time to n, Go blocks until a task can be started.
+// A negative n means no limit. It must not be called while any task is running.
+func (g *Group[T]) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic("SetLimit: limit modified while tasks are running")
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// SetCollectErrors switches the group into collect all errors mode if collect is true,
+// a failed task no longer cancels the others, and Wait returns all errors joined together in the order the tasks are added.
+// It must be called before Go.
+func (g *Group[T]) SetCollectErrors(collect bool) {
+	g.collectAll = collect
+}
+
+// Go runs task in a new goroutine, it blocks while the number of running tasks has reached the limit.
+func (g *Group[T]) Go(task func(ctx context.Context) (T, error)) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(task)
+}
+
+// TryGo runs task in a new goroutine only if the number of running tasks is below the limit,
+// it reports whether the task is started.
+func (g *Group[T]) TryGo(task func(ctx context.Context) (T, error)) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(task)
+	return true
+}
+
+// Wait blocks until all tasks are finished, then returns their results in the order the tasks are added.
+// The result of a failed task is the zero value of T. The error is the first error in fail fast mode,
+// or all errors joined together in collect all errors mode.
+func (g *Group[T]) Wait() ([]T, error) {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.collectAll {
+		return g.results, internal.JoinError(g.errs...)
+	}
+	return g.results, g.err
+}
+
+func (g *Group[T]) start(task func(ctx context.Context) (T, error)) {
+	g.mu.Lock()
+	index := len(g.results)
+	var zeroValue T
+	g.results = append(g.results, zeroValue)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+
+		value, err := runTask(func() (T, error) {
+			return task(g.ctx)
+		})
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		if err == nil {
+			g.results[index] = value
+			return
+		}
+
+		g.errs[index] = err
+		if g.err == nil {
+			g.err = err
+			if !g.collectAll {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// ForEachParallel calls fn for every item of items in goroutines, at most limit calls run at the same time,
+// limit <= 0 means no limit. The first error cancels ctx of the other calls and is returned.
+func ForEachParallel[T any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, index int, item T) error) error {
+	_, err := MapParallel(ctx, items, limit, func(ctx context.Context, index int, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, index, item)
+	})
+
+	return err
+}
+
+// MapParallel maps every item of items with fn in goroutines and returns the results in the order of items,
+// at most limit calls run at the same time, limit <= 0 means no limit. The first error cancels ctx of the other calls and is returned.
+func MapParallel[T any, U any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, index int, item T) (U, error)) ([]U, error) {
+	group := NewGroup[U](ctx)
+	if limit > 0 {
+		group.SetLimit(limit)
+	}
+
+	for i, item := range items {
+		index, item := i, item
+		group.Go(func(ctx context.Context) (U, error) {
+			return fn(ctx, index, item)
+		})
+	}
+
+	return group.Wait()
+}
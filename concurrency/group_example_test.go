@@ -0,0 +1,66 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func ExampleGroup() {
+	group := NewGroup[int](context.Background())
+	group.SetLimit(2)
+
+	for i := 1; i <= 3; i++ {
+		n := i
+		group.Go(func(ctx context.Context) (int, error) {
+			return n * n, nil
+		})
+	}
+
+	results, err := group.Wait()
+
+	fmt.Println(results)
+	fmt.Println(err)
+
+	// Output:
+	// [1 4 9]
+	// <nil>
+}
+
+func ExampleGroup_SetCollectErrors() {
+	group := NewGroup[int](context.Background())
+	group.SetCollectErrors(true)
+
+	group.Go(func(ctx context.Context) (int, error) {
+		return 0, errors.New("first")
+	})
+	group.Go(func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+	group.Go(func(ctx context.Context) (int, error) {
+		return 0, errors.New("third")
+	})
+
+	results, err := group.Wait()
+
+	fmt.Println(results)
+	fmt.Println(err)
+
+	// Output:
+	// [0 2 0]
+	// first
+	// third
+}
+
+func ExampleMapParallel() {
+	result, err := MapParallel(context.Background(), []string{"a", "b", "c"}, 2, func(ctx context.Context, index int, item string) (string, error) {
+		return fmt.Sprintf("%d:%s", index, item), nil
+	})
+
+	fmt.Println(result)
+	fmt.Println(err)
+
+	// Output:
+	// [0:a 1:b 2:c]
+	// <nil>
+}
@@ -0,0 +1,175 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestGroup_Wait(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_Wait")
+
+	group := NewGroup[int](context.Background())
+	for i := 0; i < 5; i++ {
+		n := i
+		group.Go(func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(5-n) * time.Millisecond)
+			return n * 2, nil
+		})
+	}
+
+	results, err := group.Wait()
+	assert.IsNil(err)
+	assert.Equal([]int{0, 2, 4, 6, 8}, results)
+}
+
+func TestGroup_FirstError(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_FirstError")
+
+	errFail := errors.New("fail")
+
+	group := NewGroup[int](context.Background())
+	group.Go(func(ctx context.Context) (int, error) {
+		return 0, errFail
+	})
+	group.Go(func(ctx context.Context) (int, error) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Second):
+			return 1, nil
+		}
+	})
+
+	results, err := group.Wait()
+	assert.Equal(errFail, err)
+	assert.Equal([]int{0, 0}, results)
+}
+
+func TestGroup_CollectErrors(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_CollectErrors")
+
+	err1 := errors.New("err1")
+	err2 := errors.New("err2")
+
+	group := NewGroup[string](context.Background())
+	group.SetCollectErrors(true)
+
+	group.Go(func(ctx context.Context) (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "", err1
+	})
+	group.Go(func(ctx context.Context) (string, error) {
+		return "", err2
+	})
+	group.Go(func(ctx context.Context) (string, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "c", ctx.Err()
+	})
+
+	results, err := group.Wait()
+	assert.Equal([]string{"", "", "c"}, results)
+	assert.Equal(true, errors.Is(err, err1))
+	assert.Equal(true, errors.Is(err, err2))
+	assert.Equal("err1\nerr2", err.Error())
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_SetLimit")
+
+	var running, maxRunning int32
+
+	group := NewGroup[int](context.Background())
+	group.SetLimit(2)
+
+	for i := 0; i < 6; i++ {
+		group.Go(func(ctx context.Context) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 1, nil
+		})
+	}
+
+	results, err := group.Wait()
+	assert.IsNil(err)
+	assert.Equal(6, len(results))
+	assert.Equal(int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestGroup_TryGo(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_TryGo")
+
+	release := make(chan struct{})
+
+	group := NewGroup[int](context.Background())
+	group.SetLimit(1)
+
+	assert.Equal(true, group.TryGo(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	}))
+	assert.Equal(false, group.TryGo(func(ctx context.Context) (int, error) {
+		return 2, nil
+	}))
+
+	close(release)
+	results, err := group.Wait()
+	assert.IsNil(err)
+	assert.Equal([]int{1}, results)
+}
+
+func TestGroup_Panic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroup_Panic")
+
+	group := NewGroup[int](context.Background())
+	group.Go(func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+
+	_, err := group.Wait()
+	var panicErr *PanicError
+	assert.Equal(true, errors.As(err, &panicErr))
+	assert.Equal("boom", panicErr.Value)
+}
+
+func TestMapParallel(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMapParallel")
+
+	result, err := MapParallel(context.Background(), []int{1, 2, 3, 4}, 2, func(ctx context.Context, index, item int) (string, error) {
+		return string(rune('a' + index*item)), nil
+	})
+	assert.IsNil(err)
+	assert.Equal([]string{"a", "c", "g", "m"}, result)
+
+	errFail := errors.New("fail")
+	_, err = MapParallel(context.Background(), []int{1, 2, 3}, 0, func(ctx context.Context, index, item int) (int, error) {
+		if item == 2 {
+			return 0, errFail
+		}
+		return item, nil
+	})
+	assert.Equal(errFail, err)
+}
+
+func TestForEachParallel(t *testing.T) {
+	assert := internal.NewAssert(t, "TestForEachParallel")
+
+	var sum int64
+	err := ForEachParallel(context.Background(), []int64{1, 2, 3, 4}, 3, func(ctx context.Context, index int, item int64) error {
+		atomic.AddInt64(&sum, item)
+		return nil
+	})
+	assert.IsNil(err)
+	assert.Equal(int64(10), sum)
+}
@@ -0,0 +1,83 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import "sync"
+
+// KeyedMutex provides a mutex for every key, locking one key does not block the others.
+// The mutex of a key is removed once no goroutine holds or waits for it, so the number of keys is unbounded.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewKeyedMutex creates a KeyedMutex.
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{locks: make(map[K]*keyedLock)}
+}
+
+// Lock locks the key, it blocks until the key is available.
+func (km *KeyedMutex[K]) Lock(key K) {
+	km.acquire(key).mu.Lock()
+}
+
+// TryLock tries to lock the key without blocking, it reports whether the key is locked.
+func (km *KeyedMutex[K]) TryLock(key K) bool {
+	lock := km.acquire(key)
+	if lock.mu.TryLock() {
+		return true
+	}
+
+	km.release(key, lock)
+	return false
+}
+
+// Unlock unlocks the key, it panics if the key is not locked.
+func (km *KeyedMutex[K]) Unlock(key K) {
+	km.mu.Lock()
+	lock, ok := km.locks[key]
+	km.mu.Unlock()
+
+	if !ok {
+		panic("KeyedMutex: unlock of unlocked key")
+	}
+
+	lock.mu.Unlock()
+	km.release(key, lock)
+}
+
+// Len returns the number of keys which are locked or being waited for.
+func (km *KeyedMutex[K]) Len() int {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	return len(km.locks)
+}
+
+func (km *KeyedMutex[K]) acquire(key K) *keyedLock {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	lock, ok := km.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		km.locks[key] = lock
+	}
+	lock.refs++
+
+	return lock
+}
+
+func (km *KeyedMutex[K]) release(key K, lock *keyedLock) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(km.locks, key)
+	}
+}
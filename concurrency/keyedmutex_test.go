@@ -0,0 +1,41 @@
+package concurrency
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	assert := internal.NewAssert(t, "TestKeyedMutex")
+
+	km := NewKeyedMutex[string]()
+
+	km.Lock("a")
+	assert.Equal(true, km.TryLock("b"))
+	assert.Equal(false, km.TryLock("a"))
+	assert.Equal(2, km.Len())
+
+	km.Unlock("a")
+	km.Unlock("b")
+	assert.Equal(0, km.Len())
+
+	counts := make([]int, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		index := i % 2
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := []string{"x", "y"}[index]
+			km.Lock(key)
+			counts[index]++
+			km.Unlock(key)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal([]int{50, 50}, counts)
+	assert.Equal(0, km.Len())
+}
@@ -0,0 +1,374 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrPoolClosed is returned when submitting a task to a pool which has been shut down.
+	ErrPoolClosed = errors.New("pool is closed")
+	// ErrPoolFull is returned by TrySubmit when the task queue of the pool is full.
+	ErrPoolFull = errors.New("pool queue is full")
+)
+
+// PanicError is the error of a task which panicked, it keeps the recovered value and the stack of the goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+// Future is the pending result of a task submitted to a Pool.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Done returns a channel which is closed when the task is finished.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get blocks until the task is finished, then returns its result.
+func (f *Future[T]) Get() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// GetContext blocks until the task is finished or ctx is done, whichever happens first.
+// The task keeps running if ctx is done.
+func (f *Future[T]) GetContext(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zeroValue T
+		return zeroValue, ctx.Err()
+	}
+}
+
+func (f *Future[T]) complete(value T, err error) {
+	f.value = value
+	f.err = err
+	close(f.done)
+}
+
+// PoolMetrics is a snapshot of the state of a Pool.
+type PoolMetrics struct {
+	Workers   int    // number of live workers
+	Queued    int    // number of tasks waiting in the queue
+	Running   int    // number of tasks being executed
+	Completed uint64 // number of tasks finished without error
+	Failed    uint64 // number of tasks which returned an error or panicked
+}
+
+// PoolOption is for adding pool config.
+type PoolOption func(*poolConfig)
+
+type poolConfig struct {
+	minWorkers  int
+	maxWorkers  int
+	queueSize   int
+	idleTimeout time.Duration
+}
+
+// PoolWorkers makes the pool run a fixed number of workers.
+func PoolWorkers(n int) PoolOption {
+	return func(pc *poolConfig) {
+		pc.minWorkers = n
+		pc.maxWorkers = n
+	}
+}
+
+// PoolElasticWorkers makes the pool keep at least min workers, more workers are started on demand up to max,
+// and the extra workers exit after being idle for idleTimeout.
+func PoolElasticWorkers(min, max int, idleTimeout time.Duration) PoolOption {
+	return func(pc *poolConfig) {
+		pc.minWorkers = min
+		pc.maxWorkers = max
+		pc.idleTimeout = idleTimeout
+	}
+}
+
+// PoolQueueSize set the capacity of the task queue, submitting to a full queue blocks until there is room.
+func PoolQueueSize(n int) PoolOption {
+	return func(pc *poolConfig) {
+		pc.queueSize = n
+	}
+}
+
+type poolTask[T any] struct {
+	fn     func() (T, error)
+	future *Future[T]
+}
+
+// Pool is a bounded worker pool, tasks are queued in a bounded queue and executed by workers,
+// the result of every task is delivered through a Future.
+type Pool[T any] struct {
+	queued    int64
+	running   int64
+	completed uint64
+	failed    uint64
+	idle      int64
+
+	config  poolConfig
+	tasks   chan *poolTask[T]
+	quit    chan struct{}
+	mu      sync.Mutex
+	closed  bool
+	workers int
+	pending int
+
+	submitters sync.WaitGroup
+	workerWg   sync.WaitGroup
+	stopped    chan struct{}
+}
+
+// NewPool creates a Pool and starts its workers.
+// By default the pool runs runtime.NumCPU() fixed workers with a queue of the same size.
+func NewPool[T any](opts ...PoolOption) *Pool[T] {
+	config := poolConfig{
+		minWorkers: runtime.NumCPU(),
+		maxWorkers: runtime.NumCPU(),
+		queueSize:  -1,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.maxWorkers <= 0 {
+		panic("NewPool: max workers should be positive")
+	}
+	if config.minWorkers < 0 || config.minWorkers > config.maxWorkers {
+		panic("NewPool: min workers should be between 0 and max workers")
+	}
+	if config.queueSize < 0 {
+		config.queueSize = config.maxWorkers
+	}
+
+	p := &Pool[T]{
+		config:  config,
+		tasks:   make(chan *poolTask[T], config.queueSize),
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	for i := 0; i < config.minWorkers; i++ {
+		p.startWorker()
+	}
+	p.mu.Unlock()
+
+	return p
+}
+
+// Submit puts task into the queue and returns its future, it blocks while the queue is full.
+// It returns ErrPoolClosed if the pool has been shut down.
+func (p *Pool[T]) Submit(task func() (T, error)) (*Future[T], error) {
+	return p.submit(context.Background(), task, true)
+}
+
+// SubmitContext is like Submit, but it gives up waiting for room in the queue when ctx is done.
+func (p *Pool[T]) SubmitContext(ctx context.Context, task func() (T, error)) (*Future[T], error) {
+	return p.submit(ctx, task, true)
+}
+
+// TrySubmit is like Submit, but it returns ErrPoolFull instead of blocking if the queue is full.
+func (p *Pool[T]) TrySubmit(task func() (T, error)) (*Future[T], error) {
+	return p.submit(context.Background(), task, false)
+}
+
+// Metrics returns a snapshot of the pool state.
+func (p *Pool[T]) Metrics() PoolMetrics {
+	p.mu.Lock()
+	workers := p.workers
+	p.mu.Unlock()
+
+	return PoolMetrics{
+		Workers:   workers,
+		Queued:    int(atomic.LoadInt64(&p.queued)),
+		Running:   int(atomic.LoadInt64(&p.running)),
+		Completed: atomic.LoadUint64(&p.completed),
+		Failed:    atomic.LoadUint64(&p.failed),
+	}
+}
+
+// Shutdown stops accepting new tasks and waits until all queued and running tasks are finished or ctx is done.
+// If ctx is done first, Shutdown returns ctx.Err() and the remaining tasks keep running in the background.
+func (p *Pool[T]) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+
+		go func() {
+			// no one can send to tasks after the blocked submitters are released
+			p.submitters.Wait()
+			close(p.tasks)
+			p.workerWg.Wait()
+			close(p.stopped)
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool[T]) submit(ctx context.Context, fn func() (T, error), block bool) (*Future[T], error) {
+	if fn == nil {
+		panic("Submit: task should not be nil")
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	// all workers are busy, grow the pool if it is elastic
+	if atomic.LoadInt64(&p.idle) == 0 && p.workers < p.config.maxWorkers {
+		p.startWorker()
+	}
+	p.pending++
+	p.submitters.Add(1)
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.pending--
+		p.mu.Unlock()
+		p.submitters.Done()
+	}()
+
+	task := &poolTask[T]{fn: fn, future: newFuture[T]()}
+
+	atomic.AddInt64(&p.queued, 1)
+
+	if !block {
+		select {
+		case p.tasks <- task:
+			return task.future, nil
+		default:
+			atomic.AddInt64(&p.queued, -1)
+			return nil, ErrPoolFull
+		}
+	}
+
+	select {
+	case p.tasks <- task:
+		return task.future, nil
+	case <-p.quit:
+		atomic.AddInt64(&p.queued, -1)
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		atomic.AddInt64(&p.queued, -1)
+		return nil, ctx.Err()
+	}
+}
+
+// startWorker should be called with p.mu held.
+func (p *Pool[T]) startWorker() {
+	p.workers++
+	p.workerWg.Add(1)
+	go p.worker()
+}
+
+func (p *Pool[T]) worker() {
+	defer p.workerWg.Done()
+
+	elastic := p.config.idleTimeout > 0 && p.config.minWorkers < p.config.maxWorkers
+
+	for {
+		var idleTimeout <-chan time.Time
+		var timer *time.Timer
+		if elastic {
+			timer = time.NewTimer(p.config.idleTimeout)
+			idleTimeout = timer.C
+		}
+
+		atomic.AddInt64(&p.idle, 1)
+
+		select {
+		case task, ok := <-p.tasks:
+			atomic.AddInt64(&p.idle, -1)
+			if timer != nil {
+				timer.Stop()
+			}
+			if !ok {
+				p.mu.Lock()
+				p.workers--
+				p.mu.Unlock()
+				return
+			}
+			atomic.AddInt64(&p.queued, -1)
+			p.run(task)
+		case <-idleTimeout:
+			atomic.AddInt64(&p.idle, -1)
+			if p.retire() {
+				return
+			}
+		}
+	}
+}
+
+// retire reports whether an idle worker can exit without shrinking the pool under its min workers
+// or leaving a task which is being submitted behind.
+func (p *Pool[T]) retire() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.workers > p.config.minWorkers && p.pending == 0 && len(p.tasks) == 0 {
+		p.workers--
+		return true
+	}
+	return false
+}
+
+func (p *Pool[T]) run(task *poolTask[T]) {
+	atomic.AddInt64(&p.running, 1)
+	value, err := runTask(task.fn)
+	atomic.AddInt64(&p.running, -1)
+
+	if err != nil {
+		atomic.AddUint64(&p.failed, 1)
+	} else {
+		atomic.AddUint64(&p.completed, 1)
+	}
+
+	task.future.complete(value, err)
+}
+
+// runTask executes fn and turns a panic into a *PanicError.
+func runTask[T any](fn func() (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zeroValue T
+			value = zeroValue
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,34 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+)
+
+func ExamplePool() {
+	pool := NewPool[int](PoolWorkers(2), PoolQueueSize(4))
+
+	futures := make([]*Future[int], 0, 4)
+	for i := 1; i <= 4; i++ {
+		n := i
+		future, _ := pool.Submit(func() (int, error) {
+			return n * 10, nil
+		})
+		futures = append(futures, future)
+	}
+
+	for _, future := range futures {
+		v, _ := future.Get()
+		fmt.Println(v)
+	}
+
+	pool.Shutdown(context.Background())
+	fmt.Println(pool.Metrics().Completed)
+
+	// Output:
+	// 10
+	// 20
+	// 30
+	// 40
+	// 4
+}
@@ -0,0 +1,219 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestPool_Submit(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_Submit")
+
+	pool := NewPool[int](PoolWorkers(3), PoolQueueSize(10))
+
+	futures := make([]*Future[int], 10)
+	for i := range futures {
+		n := i
+		future, err := pool.Submit(func() (int, error) {
+			return n * n, nil
+		})
+		assert.IsNil(err)
+		futures[i] = future
+	}
+
+	for i, future := range futures {
+		v, err := future.Get()
+		assert.IsNil(err)
+		assert.Equal(i*i, v)
+	}
+
+	assert.IsNil(pool.Shutdown(context.Background()))
+
+	metrics := pool.Metrics()
+	assert.Equal(uint64(10), metrics.Completed)
+	assert.Equal(uint64(0), metrics.Failed)
+	assert.Equal(0, metrics.Workers)
+}
+
+func TestPool_ErrorAndPanic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_ErrorAndPanic")
+
+	pool := NewPool[string](PoolWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	errFail := errors.New("fail")
+	f1, _ := pool.Submit(func() (string, error) { return "", errFail })
+	f2, _ := pool.Submit(func() (string, error) { panic("boom") })
+	f3, _ := pool.Submit(func() (string, error) { return "ok", nil })
+
+	_, err := f1.Get()
+	assert.Equal(errFail, err)
+
+	_, err = f2.Get()
+	var panicErr *PanicError
+	assert.Equal(true, errors.As(err, &panicErr))
+	assert.Equal("boom", panicErr.Value)
+	assert.Equal(true, len(panicErr.Stack) > 0)
+
+	v, err := f3.Get()
+	assert.IsNil(err)
+	assert.Equal("ok", v)
+
+	metrics := pool.Metrics()
+	assert.Equal(uint64(1), metrics.Completed)
+	assert.Equal(uint64(2), metrics.Failed)
+}
+
+func TestPool_BackPressure(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_BackPressure")
+
+	pool := NewPool[int](PoolWorkers(1), PoolQueueSize(1))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	task := func() (int, error) {
+		<-release
+		return 1, nil
+	}
+
+	pool.Submit(func() (int, error) {
+		close(started)
+		return task()
+	})
+	<-started
+
+	_, err := pool.TrySubmit(task)
+	assert.IsNil(err)
+
+	_, err = pool.TrySubmit(task)
+	assert.Equal(ErrPoolFull, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = pool.SubmitContext(ctx, task)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	metrics := pool.Metrics()
+	assert.Equal(1, metrics.Running)
+	assert.Equal(1, metrics.Queued)
+
+	close(release)
+	assert.IsNil(pool.Shutdown(context.Background()))
+	assert.Equal(uint64(2), pool.Metrics().Completed)
+}
+
+func TestPool_Shutdown(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_Shutdown")
+
+	pool := NewPool[int](PoolWorkers(2), PoolQueueSize(5))
+
+	var mu sync.Mutex
+	done := 0
+	for i := 0; i < 5; i++ {
+		pool.Submit(func() (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			done++
+			mu.Unlock()
+			return 0, nil
+		})
+	}
+
+	assert.IsNil(pool.Shutdown(context.Background()))
+	assert.Equal(5, done)
+
+	_, err := pool.Submit(func() (int, error) { return 0, nil })
+	assert.Equal(ErrPoolClosed, err)
+
+	// shutdown is idempotent
+	assert.IsNil(pool.Shutdown(context.Background()))
+}
+
+func TestPool_ShutdownTimeout(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_ShutdownTimeout")
+
+	pool := NewPool[int](PoolWorkers(1))
+
+	release := make(chan struct{})
+	pool.Submit(func() (int, error) {
+		<-release
+		return 0, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, pool.Shutdown(ctx))
+
+	close(release)
+	assert.IsNil(pool.Shutdown(context.Background()))
+}
+
+func TestPool_Elastic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPool_Elastic")
+
+	pool := NewPool[int](PoolElasticWorkers(0, 4, 20*time.Millisecond), PoolQueueSize(0))
+	assert.Equal(0, pool.Metrics().Workers)
+
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(4)
+
+	futures := make([]*Future[int], 4)
+	for i := range futures {
+		futures[i], _ = pool.Submit(func() (int, error) {
+			started.Done()
+			<-release
+			return 1, nil
+		})
+	}
+	started.Wait()
+	assert.Equal(4, pool.Metrics().Workers)
+
+	close(release)
+	for _, future := range futures {
+		v, err := future.Get()
+		assert.IsNil(err)
+		assert.Equal(1, v)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for pool.Metrics().Workers > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(0, pool.Metrics().Workers)
+
+	f, err := pool.Submit(func() (int, error) { return 2, nil })
+	assert.IsNil(err)
+	v, _ := f.Get()
+	assert.Equal(2, v)
+
+	assert.IsNil(pool.Shutdown(context.Background()))
+}
+
+func TestFuture_GetContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFuture_GetContext")
+
+	pool := NewPool[int](PoolWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	future, _ := pool.Submit(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := future.GetContext(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	close(release)
+	<-future.Done()
+	v, err := future.GetContext(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+}
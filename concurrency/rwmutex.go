@@ -0,0 +1,163 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RWMutex is a reader/writer mutual exclusion lock which supports try-lock, lock with timeout and lock with context.
+// Waiting writers take precedence over new readers, so writers are not starved. The zero value is an unlocked mutex.
+type RWMutex struct {
+	mu             sync.Mutex
+	readers        int
+	writer         bool
+	waitingWriters int
+	changed        chan struct{}
+}
+
+// Lock locks m for writing, it blocks until the lock is available.
+func (m *RWMutex) Lock() {
+	_ = m.LockContext(context.Background())
+}
+
+// TryLock tries to lock m for writing without blocking, it reports whether the lock is acquired.
+func (m *RWMutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer || m.readers > 0 {
+		return false
+	}
+	m.writer = true
+	return true
+}
+
+// LockTimeout tries to lock m for writing until timeout has passed, it reports whether the lock is acquired.
+func (m *RWMutex) LockTimeout(timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return m.LockContext(ctx) == nil
+}
+
+// LockContext locks m for writing, it blocks until the lock is available or ctx is done.
+func (m *RWMutex) LockContext(ctx context.Context) error {
+	m.mu.Lock()
+	m.waitingWriters++
+
+	for {
+		if !m.writer && m.readers == 0 {
+			m.waitingWriters--
+			m.writer = true
+			m.mu.Unlock()
+			return nil
+		}
+
+		changed := m.wait()
+		m.mu.Unlock()
+
+		select {
+		case <-changed:
+			m.mu.Lock()
+		case <-ctx.Done():
+			m.mu.Lock()
+			m.waitingWriters--
+			// readers blocked by this writer may proceed now
+			m.broadcast()
+			m.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// Unlock unlocks m for writing, it panics if m is not locked for writing.
+func (m *RWMutex) Unlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.writer {
+		panic("RWMutex: unlock of unlocked mutex")
+	}
+	m.writer = false
+	m.broadcast()
+}
+
+// RLock locks m for reading, it blocks until the lock is available.
+func (m *RWMutex) RLock() {
+	_ = m.RLockContext(context.Background())
+}
+
+// TryRLock tries to lock m for reading without blocking, it reports whether the lock is acquired.
+func (m *RWMutex) TryRLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer || m.waitingWriters > 0 {
+		return false
+	}
+	m.readers++
+	return true
+}
+
+// RLockTimeout tries to lock m for reading until timeout has passed, it reports whether the lock is acquired.
+func (m *RWMutex) RLockTimeout(timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return m.RLockContext(ctx) == nil
+}
+
+// RLockContext locks m for reading, it blocks until the lock is available or ctx is done.
+func (m *RWMutex) RLockContext(ctx context.Context) error {
+	m.mu.Lock()
+
+	for {
+		if !m.writer && m.waitingWriters == 0 {
+			m.readers++
+			m.mu.Unlock()
+			return nil
+		}
+
+		changed := m.wait()
+		m.mu.Unlock()
+
+		select {
+		case <-changed:
+			m.mu.Lock()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RUnlock undoes a single RLock call, it panics if m is not locked for reading.
+func (m *RWMutex) RUnlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.readers == 0 {
+		panic("RWMutex: runlock of unlocked mutex")
+	}
+	m.readers--
+	if m.readers == 0 {
+		m.broadcast()
+	}
+}
+
+// wait returns a channel which is closed on the next state change, it should be called with m.mu held.
+func (m *RWMutex) wait() <-chan struct{} {
+	if m.changed == nil {
+		m.changed = make(chan struct{})
+	}
+	return m.changed
+}
+
+// broadcast wakes up all waiters, it should be called with m.mu held.
+func (m *RWMutex) broadcast() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
@@ -0,0 +1,87 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestRWMutex(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRWMutex")
+
+	var m RWMutex
+
+	m.RLock()
+	assert.Equal(true, m.TryRLock())
+	assert.Equal(false, m.TryLock())
+	assert.Equal(false, m.LockTimeout(10*time.Millisecond))
+	m.RUnlock()
+	m.RUnlock()
+
+	m.Lock()
+	assert.Equal(false, m.TryRLock())
+	assert.Equal(false, m.RLockTimeout(10*time.Millisecond))
+	assert.Equal(false, m.TryLock())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(context.Canceled, m.LockContext(ctx))
+	m.Unlock()
+
+	assert.Equal(true, m.TryLock())
+	m.Unlock()
+}
+
+func TestRWMutex_WriterPreference(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRWMutex_WriterPreference")
+
+	var m RWMutex
+	m.RLock()
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock()
+		close(locked)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// a waiting writer blocks new readers
+	assert.Equal(false, m.TryRLock())
+
+	m.RUnlock()
+	<-locked
+	m.Unlock()
+
+	assert.Equal(true, m.TryRLock())
+	m.RUnlock()
+}
+
+func TestRWMutex_Concurrent(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRWMutex_Concurrent")
+
+	var m RWMutex
+	var wg sync.WaitGroup
+	counter := 0
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.Lock()
+			counter++
+			m.Unlock()
+		}()
+		go func() {
+			defer wg.Done()
+			m.RLock()
+			_ = counter
+			m.RUnlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(50, counter)
+}
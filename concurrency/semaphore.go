@@ -0,0 +1,122 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Semaphore is a weighted semaphore, waiters acquire weights in the order they arrive,
+// so a large request is not starved by smaller ones.
+type Semaphore struct {
+	size    int64
+	cur     int64
+	mu      sync.Mutex
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// NewSemaphore creates a Semaphore with the given total weight.
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire acquires the weight n, it blocks until the weight is available or ctx is done.
+// On failure it returns ctx.Err() and leaves the semaphore unchanged.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	if n > s.size {
+		// the weight can never be acquired, just wait for ctx
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(semaphoreWaiter{n: n, ready: ready})
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// acquired after ctx is done, give it back
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// the waiters behind the removed front waiter may be able to proceed now
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires the weight n without blocking, it reports whether the weight is acquired.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release releases the weight n.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cur -= n
+	if s.cur < 0 {
+		panic("Semaphore: released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// Available returns the weight which can be acquired now.
+func (s *Semaphore) Available() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.size - s.cur
+}
+
+func (s *Semaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+
+		w := front.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			// keep FIFO order, do not let smaller waiters overtake the front one
+			return
+		}
+
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,22 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+)
+
+func ExampleSemaphore() {
+	sem := NewSemaphore(3)
+
+	sem.Acquire(context.Background(), 2)
+	fmt.Println(sem.Available())
+	fmt.Println(sem.TryAcquire(2))
+
+	sem.Release(2)
+	fmt.Println(sem.Available())
+
+	// Output:
+	// 1
+	// false
+	// 3
+}
@@ -0,0 +1,92 @@
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestSemaphore(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSemaphore")
+
+	sem := NewSemaphore(3)
+
+	assert.IsNil(sem.Acquire(context.Background(), 2))
+	assert.Equal(int64(1), sem.Available())
+	assert.Equal(false, sem.TryAcquire(2))
+	assert.Equal(true, sem.TryAcquire(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, sem.Acquire(ctx, 1))
+
+	acquired := make(chan struct{})
+	go func() {
+		sem.Acquire(context.Background(), 3)
+		close(acquired)
+	}()
+
+	sem.Release(2)
+	select {
+	case <-acquired:
+		t.Fatal("acquired before enough weight is released")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	sem.Release(1)
+	<-acquired
+	assert.Equal(int64(0), sem.Available())
+
+	sem.Release(3)
+	assert.Equal(int64(3), sem.Available())
+}
+
+func TestSemaphore_FIFO(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSemaphore_FIFO")
+
+	sem := NewSemaphore(2)
+	sem.Acquire(context.Background(), 2)
+
+	// a large waiter at the front blocks smaller requests behind it
+	bigDone := make(chan struct{})
+	go func() {
+		sem.Acquire(context.Background(), 2)
+		close(bigDone)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	sem.Release(1)
+	assert.Equal(false, sem.TryAcquire(1))
+
+	sem.Release(1)
+	<-bigDone
+	assert.Equal(int64(0), sem.Available())
+}
+
+func TestSemaphore_CancelFrontWaiter(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSemaphore_CancelFrontWaiter")
+
+	sem := NewSemaphore(2)
+	sem.Acquire(context.Background(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bigErr := make(chan error)
+	go func() {
+		bigErr <- sem.Acquire(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	smallDone := make(chan struct{})
+	go func() {
+		sem.Acquire(context.Background(), 1)
+		close(smallDone)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	assert.Equal(context.Canceled, <-bigErr)
+	<-smallDone
+	assert.Equal(int64(0), sem.Available())
+}
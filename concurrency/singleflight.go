@@ -0,0 +1,98 @@
+// Package concurrency contain some functions to support concurrent programming. eg, goroutine, channel.
+package concurrency
+
+import "sync"
+
+// SingleFlight suppresses duplicate calls, concurrent calls of Do with the same key share the result of a single execution.
+// A panic in the function is recovered and returned to every caller as a *PanicError.
+type SingleFlight[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+type flightCall[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	err   error
+	dups  int
+	chans []chan<- FlightResult[V]
+}
+
+// FlightResult is the result of SingleFlight.DoChan.
+type FlightResult[V any] struct {
+	Value  V
+	Err    error
+	Shared bool
+}
+
+// NewSingleFlight creates a SingleFlight.
+func NewSingleFlight[K comparable, V any]() *SingleFlight[K, V] {
+	return &SingleFlight[K, V]{calls: make(map[K]*flightCall[V])}
+}
+
+// Do executes fn for the key and returns its result, if there is an execution in flight for the key,
+// Do waits for it and returns its result. shared reports whether the result is given to multiple callers.
+func (sf *SingleFlight[K, V]) Do(key K, fn func() (V, error)) (value V, err error, shared bool) {
+	sf.mu.Lock()
+	if call, ok := sf.calls[key]; ok {
+		call.dups++
+		sf.mu.Unlock()
+
+		call.wg.Wait()
+		return call.value, call.err, true
+	}
+
+	call := &flightCall[V]{}
+	call.wg.Add(1)
+	sf.calls[key] = call
+	sf.mu.Unlock()
+
+	sf.call(key, call, fn)
+
+	return call.value, call.err, call.dups > 0
+}
+
+// DoChan is like Do, but returns a channel which receives the result when it is ready.
+func (sf *SingleFlight[K, V]) DoChan(key K, fn func() (V, error)) <-chan FlightResult[V] {
+	ch := make(chan FlightResult[V], 1)
+
+	sf.mu.Lock()
+	if call, ok := sf.calls[key]; ok {
+		call.dups++
+		call.chans = append(call.chans, ch)
+		sf.mu.Unlock()
+		return ch
+	}
+
+	call := &flightCall[V]{chans: []chan<- FlightResult[V]{ch}}
+	call.wg.Add(1)
+	sf.calls[key] = call
+	sf.mu.Unlock()
+
+	go sf.call(key, call, fn)
+
+	return ch
+}
+
+// Forget makes the next call of the key execute fn again instead of waiting for the one in flight.
+func (sf *SingleFlight[K, V]) Forget(key K) {
+	sf.mu.Lock()
+	delete(sf.calls, key)
+	sf.mu.Unlock()
+}
+
+func (sf *SingleFlight[K, V]) call(key K, call *flightCall[V], fn func() (V, error)) {
+	call.value, call.err = runTask(fn)
+
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	call.wg.Done()
+	if sf.calls[key] == call {
+		delete(sf.calls, key)
+	}
+
+	for _, ch := range call.chans {
+		ch <- FlightResult[V]{Value: call.value, Err: call.err, Shared: call.dups > 0}
+	}
+}
@@ -0,0 +1,22 @@
+package concurrency
+
+import (
+	"fmt"
+)
+
+func ExampleSingleFlight_Do() {
+	sf := NewSingleFlight[string, string]()
+
+	value, err, shared := sf.Do("config", func() (string, error) {
+		return "loaded", nil
+	})
+
+	fmt.Println(value)
+	fmt.Println(err)
+	fmt.Println(shared)
+
+	// Output:
+	// loaded
+	// <nil>
+	// false
+}
@@ -0,0 +1,94 @@
+package concurrency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestSingleFlight_Do(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSingleFlight_Do")
+
+	sf := NewSingleFlight[string, int]()
+
+	var calls int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	shared := make([]bool, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, shared[i] = sf.Do("key", fn)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+	assert.Equal([]int{42, 42, 42, 42, 42}, results)
+	assert.Equal([]bool{true, true, true, true, true}, shared)
+
+	// the key is released after the call
+	v, err, isShared := sf.Do("key", func() (int, error) { return 1, errors.New("fail") })
+	assert.Equal(1, v)
+	assert.Equal("fail", err.Error())
+	assert.Equal(false, isShared)
+}
+
+func TestSingleFlight_DoChan(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSingleFlight_DoChan")
+
+	sf := NewSingleFlight[int, string]()
+
+	release := make(chan struct{})
+	ch1 := sf.DoChan(1, func() (string, error) {
+		<-release
+		return "a", nil
+	})
+	ch2 := sf.DoChan(1, func() (string, error) {
+		return "b", nil
+	})
+	close(release)
+
+	r1, r2 := <-ch1, <-ch2
+	assert.Equal("a", r1.Value)
+	assert.Equal("a", r2.Value)
+	assert.Equal(true, r1.Shared)
+}
+
+func TestSingleFlight_ForgetAndPanic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSingleFlight_ForgetAndPanic")
+
+	sf := NewSingleFlight[string, int]()
+
+	release := make(chan struct{})
+	ch := sf.DoChan("key", func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	sf.Forget("key")
+	v, _, _ := sf.Do("key", func() (int, error) { return 2, nil })
+	assert.Equal(2, v)
+
+	close(release)
+	assert.Equal(1, (<-ch).Value)
+
+	_, err, _ := sf.Do("key", func() (int, error) { panic("boom") })
+	var panicErr *PanicError
+	assert.Equal(true, errors.As(err, &panicErr))
+}
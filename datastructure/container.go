@@ -0,0 +1,65 @@
+package datastructure
+
+import (
+	"fmt"
+
+	"github.com/serialt/lancet/iterator"
+)
+
+// Container is the common interface implemented by all data structures.
+type Container interface {
+	fmt.Stringer
+
+	// Size returns the number of elements in the container.
+	Size() int
+	// IsEmpty checks if the container has no element or not.
+	IsEmpty() bool
+}
+
+// Collection is a container of values of type T which can be iterated.
+type Collection[T any] interface {
+	Container
+
+	// Values returns a slice of all elements in iteration order.
+	Values() []T
+	// Contains checks if the value is in the collection or not.
+	Contains(value T) bool
+	// Iterator returns an iterator over the elements in iteration order.
+	Iterator() iterator.Iterator[T]
+}
+
+// MapContainer is a container of key value entries which can be iterated.
+type MapContainer[K any, V any] interface {
+	Container
+
+	// Iterator returns an iterator over the entries in iteration order, the key is First and the value is Second.
+	Iterator() iterator.Iterator[iterator.Pair[K, V]]
+}
+
+// Queue is a collection which hands out its elements in FIFO or priority order.
+type Queue[T any] interface {
+	Collection[T]
+
+	// Offer inserts value into the queue, returns false if the queue rejects it, eg. the queue is full.
+	Offer(value T) bool
+	// Poll removes the head of the queue and returns it, returns zero value and false if the queue is empty.
+	Poll() (T, bool)
+	// Peek returns the head of the queue without removing it, returns zero value and false if the queue is empty.
+	Peek() (T, bool)
+	// Clear removes all elements of the queue.
+	Clear()
+}
+
+// Stack is a collection which hands out its elements in LIFO order.
+type Stack[T any] interface {
+	Collection[T]
+
+	// Push inserts value at the top of the stack.
+	Push(value T)
+	// Poll removes the top element of the stack and returns it, returns zero value and false if the stack is empty.
+	Poll() (T, bool)
+	// Peek returns the top element of the stack without removing it, returns zero value and false if the stack is empty.
+	Peek() (T, bool)
+	// Clear removes all elements of the stack.
+	Clear()
+}
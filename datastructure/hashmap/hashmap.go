@@ -5,10 +5,17 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"strings"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.MapContainer[any, any] = (*HashMap)(nil)
+
 var defaultMapCapacity uint64 = 1 << 10
 
 type mapNode struct {
@@ -133,6 +140,52 @@ func (hm *HashMap) Values() []any {
 	return values
 }
 
+// Iterator returns an iterator over a snapshot of the key value pairs of hashmap (random order)
+func (hm *HashMap) Iterator() iterator.Iterator[iterator.Pair[any, any]] {
+	entries := make([]iterator.Pair[any, any], 0, int(hm.size))
+	hm.Iterate(func(key, value any) {
+		entries = append(entries, iterator.Pair[any, any]{First: key, Second: value})
+	})
+
+	return iterator.FromSlice(entries)
+}
+
+// Size return the number of key value pairs in hashmap
+func (hm *HashMap) Size() int {
+	return int(hm.size)
+}
+
+// IsEmpty checks if hashmap is empty or not
+func (hm *HashMap) IsEmpty() bool {
+	return hm.size == 0
+}
+
+// Clear remove all key value pairs in hashmap
+func (hm *HashMap) Clear() {
+	hm.table = make([]*mapNode, hm.capacity)
+	hm.size = 0
+}
+
+// String returns the key value pairs of hashmap (random order), eg. map[a:1 b:2]
+func (hm *HashMap) String() string {
+	pairs := make([]string, 0, hm.size)
+	hm.Iterate(func(key, value any) {
+		pairs = append(pairs, fmt.Sprintf("%v:%v", key, value))
+	})
+
+	return "map[" + strings.Join(pairs, " ") + "]"
+}
+
+// MarshalJSON encodes hashmap as a json object, keys are formatted with fmt.Sprint
+func (hm *HashMap) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, hm.size)
+	hm.Iterate(func(key, value any) {
+		m[fmt.Sprint(key)] = value
+	})
+
+	return json.Marshal(m)
+}
+
 func (hm *HashMap) resize() {
 	hm.capacity <<= 1
 
@@ -69,3 +69,22 @@ func TestHashMap_KeysValues(t *testing.T) {
 	assert.Equal(3, len(values))
 	assert.Equal(3, len(keys))
 }
+
+func TestHashMap_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestHashMap_Iterator")
+
+	hm := NewHashMap()
+	hm.Put("a", 1)
+	hm.Put("b", 2)
+	hm.Put("c", 3)
+
+	entries := map[any]any{}
+	iter := hm.Iterator()
+	for iter.HasNext() {
+		entry, ok := iter.Next()
+		assert.Equal(true, ok)
+		entries[entry.First] = entry.Second
+	}
+
+	assert.Equal(map[any]any{"a": 1, "b": 2, "c": 3}, entries)
+}
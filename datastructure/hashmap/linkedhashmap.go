@@ -0,0 +1,332 @@
+package datastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.MapContainer[string, any] = (*LinkedHashMap[string, any])(nil)
+
+type linkedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *linkedMapEntry[K, V]
+	next  *linkedMapEntry[K, V]
+}
+
+// LinkedHashMap is a hash map which keeps its entries in a doubly linked list, so iteration order is deterministic.
+// By default entries are kept in insertion order, in access order mode every Get or Put moves the entry to the end,
+// which makes the map an LRU cache when it has a capacity.
+type LinkedHashMap[K comparable, V any] struct {
+	entries     map[K]*linkedMapEntry[K, V]
+	head        *linkedMapEntry[K, V]
+	tail        *linkedMapEntry[K, V]
+	accessOrder bool
+	capacity    int
+}
+
+// NewLinkedHashMap return a LinkedHashMap pointer which iterates in insertion order.
+// Re-inserting an existing key does not change its position.
+func NewLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{entries: make(map[K]*linkedMapEntry[K, V])}
+}
+
+// NewAccessOrderLinkedHashMap return a LinkedHashMap pointer which iterates from least recently accessed entry to most recently accessed entry.
+// If capacity is positive, putting a new key into a full map evicts the least recently accessed entry.
+func NewAccessOrderLinkedHashMap[K comparable, V any](capacity int) *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{
+		entries:     make(map[K]*linkedMapEntry[K, V]),
+		accessOrder: true,
+		capacity:    capacity,
+	}
+}
+
+// Put new key value in the map
+func (m *LinkedHashMap[K, V]) Put(key K, value V) {
+	if entry, ok := m.entries[key]; ok {
+		entry.value = value
+		if m.accessOrder {
+			m.moveToBack(entry)
+		}
+		return
+	}
+
+	if m.capacity > 0 && len(m.entries) >= m.capacity {
+		m.remove(m.head)
+	}
+
+	entry := &linkedMapEntry[K, V]{key: key, value: value}
+	m.entries[key] = entry
+	m.pushBack(entry)
+}
+
+// Get return the value of given key in the map, and true if the key exists
+func (m *LinkedHashMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := m.entries[key]
+	if !ok {
+		var zeroValue V
+		return zeroValue, false
+	}
+
+	if m.accessOrder {
+		m.moveToBack(entry)
+	}
+
+	return entry.value, true
+}
+
+// GetOrDefault return the value of given key in the map, or defaultValue if the key does not exist
+func (m *LinkedHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// Delete item by given key in the map, returns false if the key does not exist
+func (m *LinkedHashMap[K, V]) Delete(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	m.remove(entry)
+
+	return true
+}
+
+// Contains checks if given key is in the map or not, it does not change the access order
+func (m *LinkedHashMap[K, V]) Contains(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Size return the number of entries in the map
+func (m *LinkedHashMap[K, V]) Size() int {
+	return len(m.entries)
+}
+
+// IsEmpty checks if the map is empty or not
+func (m *LinkedHashMap[K, V]) IsEmpty() bool {
+	return len(m.entries) == 0
+}
+
+// Clear remove all entries in the map
+func (m *LinkedHashMap[K, V]) Clear() {
+	m.entries = make(map[K]*linkedMapEntry[K, V])
+	m.head = nil
+	m.tail = nil
+}
+
+// First return the eldest entry of the map
+func (m *LinkedHashMap[K, V]) First() (K, V, bool) {
+	if m.head == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return m.head.key, m.head.value, true
+}
+
+// Last return the newest entry of the map
+func (m *LinkedHashMap[K, V]) Last() (K, V, bool) {
+	if m.tail == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return m.tail.key, m.tail.value, true
+}
+
+// Iterate executes iteratee funcation for every key and value pair of the map in order,
+// iteration stops if iteratee returns false
+func (m *LinkedHashMap[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	for entry := m.head; entry != nil; entry = entry.next {
+		if !iteratee(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// Iterator returns an iterator over a snapshot of the entries of the map in order
+func (m *LinkedHashMap[K, V]) Iterator() iterator.Iterator[iterator.Pair[K, V]] {
+	entries := make([]iterator.Pair[K, V], 0, len(m.entries))
+	for entry := m.head; entry != nil; entry = entry.next {
+		entries = append(entries, iterator.Pair[K, V]{First: entry.key, Second: entry.value})
+	}
+
+	return iterator.FromSlice(entries)
+}
+
+// Keys returns a slice of the map's keys in order
+func (m *LinkedHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for entry := m.head; entry != nil; entry = entry.next {
+		keys = append(keys, entry.key)
+	}
+	return keys
+}
+
+// Values returns a slice of the map's values in order
+func (m *LinkedHashMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.entries))
+	for entry := m.head; entry != nil; entry = entry.next {
+		values = append(values, entry.value)
+	}
+	return values
+}
+
+// String returns the entries of the map in order, eg. map[a:1 b:2]
+func (m *LinkedHashMap[K, V]) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("map[")
+	for entry := m.head; entry != nil; entry = entry.next {
+		if entry != m.head {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(fmt.Sprintf("%v:%v", entry.key, entry.value))
+	}
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// MarshalJSON encodes the map as a json object whose members keep the order of the map, keys are formatted with fmt.Sprint
+func (m *LinkedHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for entry := m.head; entry != nil; entry = entry.next {
+		if entry != m.head {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(fmt.Sprint(entry.key))
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a json object and replaces the map entries with its members in order, the order mode and capacity are kept
+func (m *LinkedHashMap[K, V]) UnmarshalJSON(data []byte) error {
+	decoded := &LinkedHashMap[K, V]{
+		entries:     make(map[K]*linkedMapEntry[K, V]),
+		accessOrder: m.accessOrder,
+		capacity:    m.capacity,
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("LinkedHashMap: json object expected")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := decodeMapKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		decoded.Put(key, value)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	*m = *decoded
+
+	return nil
+}
+
+// decodeMapKey converts a json object key to K, the key is decoded as a json string first, then as a raw json value, eg. number.
+func decodeMapKey[K comparable](s string) (K, error) {
+	var key K
+
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return key, err
+	}
+	if err := json.Unmarshal(quoted, &key); err == nil {
+		return key, nil
+	}
+
+	err = json.Unmarshal([]byte(s), &key)
+
+	return key, err
+}
+
+func (m *LinkedHashMap[K, V]) pushBack(entry *linkedMapEntry[K, V]) {
+	entry.prev = m.tail
+	entry.next = nil
+
+	if m.tail == nil {
+		m.head = entry
+	} else {
+		m.tail.next = entry
+	}
+	m.tail = entry
+}
+
+func (m *LinkedHashMap[K, V]) unlink(entry *linkedMapEntry[K, V]) {
+	if entry.prev == nil {
+		m.head = entry.next
+	} else {
+		entry.prev.next = entry.next
+	}
+
+	if entry.next == nil {
+		m.tail = entry.prev
+	} else {
+		entry.next.prev = entry.prev
+	}
+
+	entry.prev = nil
+	entry.next = nil
+}
+
+func (m *LinkedHashMap[K, V]) moveToBack(entry *linkedMapEntry[K, V]) {
+	if m.tail == entry {
+		return
+	}
+	m.unlink(entry)
+	m.pushBack(entry)
+}
+
+func (m *LinkedHashMap[K, V]) remove(entry *linkedMapEntry[K, V]) {
+	m.unlink(entry)
+	delete(m.entries, entry.key)
+}
@@ -0,0 +1,129 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestLinkedHashMap_InsertionOrder(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedHashMap_InsertionOrder")
+
+	m := NewLinkedHashMap[string, int]()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 30)
+
+	assert.Equal([]string{"c", "a", "b"}, m.Keys())
+	assert.Equal([]int{30, 1, 2}, m.Values())
+	assert.Equal("map[c:30 a:1 b:2]", m.String())
+
+	v, ok := m.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+	assert.Equal([]string{"c", "a", "b"}, m.Keys())
+
+	assert.Equal(0, m.GetOrDefault("d", 0))
+	assert.Equal(true, m.Delete("a"))
+	assert.Equal(false, m.Delete("a"))
+	assert.Equal(false, m.Contains("a"))
+	assert.Equal([]string{"c", "b"}, m.Keys())
+
+	key, value, ok := m.First()
+	assert.Equal(true, ok)
+	assert.Equal("c", key)
+	assert.Equal(30, value)
+
+	key, _, _ = m.Last()
+	assert.Equal("b", key)
+
+	m.Clear()
+	assert.Equal(true, m.IsEmpty())
+	_, _, ok = m.First()
+	assert.Equal(false, ok)
+}
+
+func TestLinkedHashMap_AccessOrder(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedHashMap_AccessOrder")
+
+	m := NewAccessOrderLinkedHashMap[string, int](3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	m.Get("a")
+	assert.Equal([]string{"b", "c", "a"}, m.Keys())
+
+	m.Put("d", 4)
+	assert.Equal([]string{"c", "a", "d"}, m.Keys())
+	assert.Equal(false, m.Contains("b"))
+
+	m.Put("c", 30)
+	assert.Equal([]string{"a", "d", "c"}, m.Keys())
+	assert.Equal(3, m.Size())
+}
+
+func TestLinkedHashMap_Iterate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedHashMap_Iterate")
+
+	m := NewLinkedHashMap[int, string]()
+	for i := 0; i < 5; i++ {
+		m.Put(i, string(rune('a'+i)))
+	}
+
+	keys := []int{}
+	m.Iterate(func(key int, value string) bool {
+		keys = append(keys, key)
+		return key < 2
+	})
+
+	assert.Equal([]int{0, 1, 2}, keys)
+}
+
+func TestLinkedHashMap_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedHashMap_JSON")
+
+	m := NewLinkedHashMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+
+	data, err := m.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal(`{"b":2,"a":1}`, string(data))
+	assert.Equal("map[b:2 a:1]", m.String())
+
+	decoded := NewLinkedHashMap[int, string]()
+	decoded.Put(3, "c")
+	err = decoded.UnmarshalJSON([]byte(`{"2":"b","1":"a"}`))
+	assert.IsNil(err)
+	assert.Equal([]int{2, 1}, decoded.Keys())
+	assert.Equal([]string{"b", "a"}, decoded.Values())
+
+	err = decoded.UnmarshalJSON([]byte(`[1]`))
+	assert.IsNotNil(err)
+	assert.Equal([]int{2, 1}, decoded.Keys())
+}
+
+func TestLinkedHashMap_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedHashMap_Iterator")
+
+	m := NewLinkedHashMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+
+	iter := m.Iterator()
+	m.Delete("a")
+
+	keys := []string{}
+	values := []int{}
+	for iter.HasNext() {
+		entry, _ := iter.Next()
+		keys = append(keys, entry.First)
+		values = append(values, entry.Second)
+	}
+
+	assert.Equal([]string{"b", "a", "c"}, keys)
+	assert.Equal([]int{2, 1, 3}, values)
+}
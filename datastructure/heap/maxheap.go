@@ -5,11 +5,18 @@
 package datastructure
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 	"github.com/serialt/lancet/lancetconstraints"
 )
 
+var _ datastructure.Queue[any] = (*MaxHeap[any])(nil)
+
 // MaxHeap implements a binary max heap
 // type T should implements Compare function in lancetconstraints.Comparator interface.
 type MaxHeap[T any] struct {
@@ -117,6 +124,78 @@ func (h *MaxHeap[T]) Data() []T {
 	return h.data
 }
 
+// Values return data of the heap, in heap order
+func (h *MaxHeap[T]) Values() []T {
+	values := make([]T, len(h.data))
+	copy(values, h.data)
+	return values
+}
+
+// IsEmpty checks if the heap is empty or not
+func (h *MaxHeap[T]) IsEmpty() bool {
+	return len(h.data) == 0
+}
+
+// Clear remove all elements of the heap
+func (h *MaxHeap[T]) Clear() {
+	h.data = make([]T, 0)
+}
+
+// Offer push value into the heap, it always returns true
+func (h *MaxHeap[T]) Offer(value T) bool {
+	h.Push(value)
+	return true
+}
+
+// Poll return the largest value, and remove it from the heap
+// if heap is empty, return zero value and fasle
+func (h *MaxHeap[T]) Poll() (T, bool) {
+	return h.Pop()
+}
+
+// Contains checks if the value is in the heap or not
+func (h *MaxHeap[T]) Contains(value T) bool {
+	return internal.ContainsValue(h.data, value)
+}
+
+// Iterator returns an iterator over a snapshot of the heap, in heap order
+func (h *MaxHeap[T]) Iterator() iterator.Iterator[T] {
+	data := make([]T, len(h.data))
+	copy(data, h.data)
+
+	return iterator.FromSlice(data)
+}
+
+// String returns data of the heap in heap order, eg. [3 1 2]
+func (h *MaxHeap[T]) String() string {
+	return internal.FormatValues(h.data)
+}
+
+// MarshalJSON encodes the heap as a json array, in heap order
+func (h *MaxHeap[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.data)
+}
+
+// UnmarshalJSON decodes a json array and replaces the heap elements with them,
+// the heap should be created by NewMaxHeap first, so it has a comparator.
+func (h *MaxHeap[T]) UnmarshalJSON(data []byte) error {
+	if h.comparator == nil {
+		return errors.New("MaxHeap: comparator is nil")
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	h.Clear()
+	for _, v := range values {
+		h.Push(v)
+	}
+
+	return nil
+}
+
 // PrintStructure print the structure of the heap
 func (h *MaxHeap[T]) PrintStructure() {
 	level := 1
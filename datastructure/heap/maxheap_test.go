@@ -1,6 +1,7 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
@@ -88,3 +89,61 @@ func TestMaxHeap_Peek(t *testing.T) {
 
 	assert.Equal(12, heap.Size())
 }
+
+func TestMaxHeap_OfferPoll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMaxHeap_OfferPoll")
+
+	heap := NewMaxHeap[int](&intComparator{})
+	for _, v := range []int{3, 1, 2} {
+		assert.Equal(true, heap.Offer(v))
+	}
+
+	assert.Equal(true, heap.Contains(2))
+	assert.Equal(false, heap.Contains(4))
+
+	data, err := heap.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[3,1,2]", string(data))
+	assert.Equal("[3 1 2]", heap.String())
+
+	val, ok := heap.Poll()
+	assert.Equal(3, val)
+	assert.Equal(true, ok)
+
+	heap.Clear()
+	assert.Equal(true, heap.IsEmpty())
+
+	_, ok = heap.Poll()
+	assert.Equal(false, ok)
+}
+
+func TestMaxHeap_Values(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMaxHeap_Values")
+
+	heap := BuildMaxHeap([]int{1, 3, 2}, &intComparator{})
+
+	values := heap.Values()
+	values[0] = 100
+
+	// Values returns a copy, the heap is not modified
+	assert.Equal([]int{3, 1, 2}, heap.Values())
+}
+
+func TestMaxHeap_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMaxHeap_JSON")
+
+	heap := BuildMaxHeap([]int{1, 3, 2}, &intComparator{})
+
+	data, err := json.Marshal(heap)
+	assert.IsNil(err)
+
+	other := NewMaxHeap[int](&intComparator{})
+	other.Push(10)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal(heap.Data(), other.Data())
+
+	max, _ := other.Pop()
+	assert.Equal(3, max)
+
+	assert.IsNotNil(json.Unmarshal(data, &MaxHeap[int]{}))
+}
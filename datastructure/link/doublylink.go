@@ -1,11 +1,16 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Collection[any] = (*DoublyLink[any])(nil)
+
 // DoublyLink is a linked list. Whose node has a generic Value, Pre pointer points to a previous node of the dl, Next pointer points to a next node of the dl.
 type DoublyLink[T any] struct {
 	Head   *datastructure.LinkNode[T]
@@ -214,7 +219,44 @@ func (dl *DoublyLink[T]) Values() []T {
 	return result
 }
 
+// Contains checks if the value is in doubly linklist or not
+func (dl *DoublyLink[T]) Contains(value T) bool {
+	return internal.ContainsValue(dl.Values(), value)
+}
+
+// Iterator returns an iterator over a snapshot of doubly linklist node values, from head to tail.
+func (dl *DoublyLink[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(dl.Values())
+}
+
+// String returns the node values of doubly linklist, eg. [1 2 3]
+func (dl *DoublyLink[T]) String() string {
+	return internal.FormatValues(dl.Values())
+}
+
+// MarshalJSON encodes the node values of doubly linklist as a json array.
+func (dl *DoublyLink[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dl.Values())
+}
+
+// UnmarshalJSON decodes a json array and replaces the nodes of doubly linklist with its elements.
+func (dl *DoublyLink[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	dl.Clear()
+	for _, v := range values {
+		dl.InsertAtTail(v)
+	}
+
+	return nil
+}
+
 // Print all nodes info of a linked list
+//
+// Deprecated: use String instead.
 func (dl *DoublyLink[T]) Print() {
 	current := dl.Head
 	info := "[ "
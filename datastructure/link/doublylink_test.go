@@ -164,3 +164,22 @@ func TestDoublyLink_Clear(t *testing.T) {
 	assert.Equal(true, link.IsEmpty())
 	assert.Equal(0, link.Size())
 }
+
+func TestDoublyLink_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDoublyLink_JSON")
+
+	link := NewDoublyLink[int]()
+	link.InsertAtTail(1)
+	link.InsertAtTail(2)
+
+	data, err := link.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[1,2]", string(data))
+
+	decoded := NewDoublyLink[int]()
+	decoded.InsertAtTail(3)
+	err = decoded.UnmarshalJSON(data)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2}, decoded.Values())
+	assert.Equal(2, decoded.Size())
+}
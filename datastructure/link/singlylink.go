@@ -1,12 +1,17 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 
 	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Collection[any] = (*SinglyLink[any])(nil)
+
 // SinglyLink is a linked list. Whose node has a Value generics and Next pointer points to a next node of the sl.
 type SinglyLink[T any] struct {
 	Head   *datastructure.LinkNode[T]
@@ -228,7 +233,44 @@ func (sl *SinglyLink[T]) Clear() {
 	sl.length = 0
 }
 
+// Contains checks if the value is in singly linklist or not
+func (sl *SinglyLink[T]) Contains(value T) bool {
+	return internal.ContainsValue(sl.Values(), value)
+}
+
+// Iterator returns an iterator over a snapshot of singly linklist node values, from head to tail.
+func (sl *SinglyLink[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(sl.Values())
+}
+
+// String returns the node values of singly linklist, eg. [1 2 3]
+func (sl *SinglyLink[T]) String() string {
+	return internal.FormatValues(sl.Values())
+}
+
+// MarshalJSON encodes the node values of singly linklist as a json array.
+func (sl *SinglyLink[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sl.Values())
+}
+
+// UnmarshalJSON decodes a json array and replaces the nodes of singly linklist with its elements.
+func (sl *SinglyLink[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	sl.Clear()
+	for _, v := range values {
+		sl.InsertAtTail(v)
+	}
+
+	return nil
+}
+
 // Print all nodes info of a linked list
+//
+// Deprecated: use String instead.
 func (sl *SinglyLink[T]) Print() {
 	current := sl.Head
 	info := "[ "
@@ -183,3 +183,29 @@ func TestSinglyLink_Clear(t *testing.T) {
 	assert.Equal(true, link.IsEmpty())
 	assert.Equal(0, link.Size())
 }
+
+func TestSinglyLink_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSinglyLink_JSON")
+
+	link := NewSinglyLink[int]()
+	link.InsertAtTail(1)
+	link.InsertAtTail(2)
+
+	data, err := link.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[1,2]", string(data))
+	assert.Equal("[1 2]", link.String())
+
+	decoded := NewSinglyLink[int]()
+	decoded.InsertAtTail(3)
+	err = decoded.UnmarshalJSON(data)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2}, decoded.Values())
+	assert.Equal(2, decoded.Size())
+	assert.Equal(true, decoded.Contains(2))
+
+	iter := decoded.Iterator()
+	val, ok := iter.Next()
+	assert.Equal(1, val)
+	assert.Equal(true, ok)
+}
@@ -5,11 +5,16 @@
 package datastructure
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 
+	"github.com/serialt/lancet/datastructure"
 	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Collection[any] = (*List[any])(nil)
+
 // List is a linear table, implemented with slice.
 type List[T any] struct {
 	data []T
@@ -406,6 +411,40 @@ func (l *List[T]) Iterator() iterator.Iterator[T] {
 	return iterator.FromSlice(l.data)
 }
 
+// Values return list data.
+func (l *List[T]) Values() []T {
+	values := make([]T, len(l.data))
+	copy(values, l.data)
+	return values
+}
+
+// Contains checks if the value is in the list or not.
+func (l *List[T]) Contains(value T) bool {
+	return l.Contain(value)
+}
+
+// String returns the list data, eg. [1 2 3]
+func (l *List[T]) String() string {
+	return fmt.Sprint(l.data)
+}
+
+// MarshalJSON encodes the list as a json array.
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.data)
+}
+
+// UnmarshalJSON decodes a json array and replaces the list data with it.
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	values := make([]T, 0)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	l.data = values
+
+	return nil
+}
+
 // ListToMap convert a list to a map based on iteratee function.
 func ListToMap[T any, K comparable, V any](list *List[T], iteratee func(T) (K, V)) map[K]V {
 	result := make(map[K]V, list.Size())
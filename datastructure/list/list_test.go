@@ -460,3 +460,19 @@ func TestListToMap(t *testing.T) {
 
 	assert.Equal(expected, result)
 }
+
+func TestList_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestList_JSON")
+
+	list := NewList([]int{1, 2, 3})
+	data, err := list.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[1,2,3]", string(data))
+	assert.Equal("[1 2 3]", list.String())
+
+	decoded := NewList([]int{})
+	err = decoded.UnmarshalJSON(data)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2, 3}, decoded.Values())
+	assert.Equal(true, decoded.Contains(2))
+}
@@ -0,0 +1,535 @@
+package datastructure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.MapContainer[string, any] = (*Map[string, any])(nil)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+	// hamtMaxShift is the shift after all bits of the hash are consumed
+	hamtMaxShift = 64
+)
+
+type hamtSlot[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	child *hamtNode[K, V]
+}
+
+// hamtNode is a bitmap indexed node, or a collision node holding keys with the same hash when collision is true.
+type hamtNode[K comparable, V any] struct {
+	edit      *editToken
+	bitmap    uint32
+	collision bool
+	slots     []hamtSlot[K, V]
+}
+
+// Map is a persistent (immutable) hash map implemented by a hash array mapped trie (HAMT).
+// Set and Delete return a new version which shares structure with the old one, the old version is never changed,
+// so a map can be passed between goroutines without copying or locking.
+type Map[K comparable, V any] struct {
+	count  int
+	root   *hamtNode[K, V]
+	hasher func(key K) uint64
+}
+
+// NewMap return an empty Map pointer, keys are hashed consistently with ==, eg. 0.0 and -0.0 have the same hash.
+func NewMap[K comparable, V any]() *Map[K, V] {
+	return NewMapWithHasher[K, V](defaultHasher[K])
+}
+
+// NewMapWithHasher return an empty Map pointer, keys are hashed by the given hasher function.
+// equal keys must have the same hash, different keys with the same hash are supported but slow down the map.
+func NewMapWithHasher[K comparable, V any](hasher func(key K) uint64) *Map[K, V] {
+	return &Map[K, V]{
+		root:   &hamtNode[K, V]{},
+		hasher: hasher,
+	}
+}
+
+func defaultHasher[K comparable](key K) uint64 {
+	h := fnv.New64a()
+	hashValue(h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+// hashValue writes v to h, values which are equal by == write the same bytes.
+func hashValue(h hash.Hash64, v reflect.Value) {
+	var buf [8]byte
+
+	writeUint := func(n uint64) {
+		binary.LittleEndian.PutUint64(buf[:], n)
+		_, _ = h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		// -0.0 == 0.0
+		if f == 0 {
+			f = 0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(real(c))
+		writeFloat(imag(c))
+	case reflect.String:
+		_, _ = h.Write([]byte(v.String()))
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		_, _ = h.Write([]byte(v.Elem().Type().String()))
+		hashValue(h, v.Elem())
+	}
+}
+
+// Len return the number of entries in the map
+func (m *Map[K, V]) Len() int {
+	return m.count
+}
+
+// Size return the number of entries in the map, it is the same as Len
+func (m *Map[K, V]) Size() int {
+	return m.count
+}
+
+// IsEmpty checks if the map is empty or not
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.count == 0
+}
+
+// Get return the value of given key in the map, and true if the key exists
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	return hamtGet(m.root, m.hasher(key), key)
+}
+
+// Contains checks if given key is in the map or not
+func (m *Map[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set return a new map in which key is associated with value
+func (m *Map[K, V]) Set(key K, value V) *Map[K, V] {
+	var added bool
+	root := hamtAssoc(nil, m.root, 0, m.hasher(key), key, value, &added)
+
+	count := m.count
+	if added {
+		count++
+	}
+
+	return &Map[K, V]{count: count, root: root, hasher: m.hasher}
+}
+
+// Delete return a new map without the given key, return the map itself if the key does not exist
+func (m *Map[K, V]) Delete(key K) *Map[K, V] {
+	var removed bool
+	root := hamtWithout(nil, m.root, 0, m.hasher(key), key, &removed)
+	if !removed {
+		return m
+	}
+	if root == nil {
+		root = &hamtNode[K, V]{}
+	}
+
+	return &Map[K, V]{count: m.count - 1, root: root, hasher: m.hasher}
+}
+
+// Iterate executes iteratee funcation for every key and value pair of the map, iteration stops if iteratee returns false.
+// the order is decided by the hash of keys, so it is the same for maps with the same keys.
+func (m *Map[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	hamtIterate(m.root, iteratee)
+}
+
+// Iterator returns an iterator over the entries of the map.
+func (m *Map[K, V]) Iterator() iterator.Iterator[iterator.Pair[K, V]] {
+	entries := make([]iterator.Pair[K, V], 0, m.Size())
+	m.Iterate(func(key K, value V) bool {
+		entries = append(entries, iterator.Pair[K, V]{First: key, Second: value})
+		return true
+	})
+
+	return iterator.FromSlice(entries)
+}
+
+// Keys returns a slice of the map's keys
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.count)
+	m.Iterate(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the map's values
+func (m *Map[K, V]) Values() []V {
+	values := make([]V, 0, m.count)
+	m.Iterate(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// ToMap returns a go map containing all entries of the map
+func (m *Map[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, m.count)
+	m.Iterate(func(key K, value V) bool {
+		result[key] = value
+		return true
+	})
+	return result
+}
+
+// String returns the entries of the map in iteration order, eg. map[a:1 b:2]
+func (m *Map[K, V]) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("map[")
+	first := true
+	m.Iterate(func(key K, value V) bool {
+		if !first {
+			builder.WriteString(" ")
+		}
+		first = false
+		builder.WriteString(fmt.Sprintf("%v:%v", key, value))
+		return true
+	})
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// MarshalJSON encodes the map as a json object in iteration order, keys are formatted with fmt.Sprint
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	buf.WriteByte('{')
+	m.Iterate(func(key K, value V) bool {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		var k, v []byte
+		if k, err = json.Marshal(fmt.Sprint(key)); err != nil {
+			return false
+		}
+		if v, err = json.Marshal(value); err != nil {
+			return false
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Transient return a mutable copy of the map for batch updates,
+// it shares structure with the map and copies nodes lazily on the first write.
+func (m *Map[K, V]) Transient() *TransientMap[K, V] {
+	return &TransientMap[K, V]{
+		edit:   &editToken{},
+		count:  m.count,
+		root:   m.root,
+		hasher: m.hasher,
+	}
+}
+
+// TransientMap is a mutable version of Map for efficient batch updates.
+// it is not safe for concurrent use, and can not be used after calling Persistent.
+type TransientMap[K comparable, V any] struct {
+	edit   *editToken
+	count  int
+	root   *hamtNode[K, V]
+	hasher func(key K) uint64
+}
+
+// Len return the number of entries in the map
+func (t *TransientMap[K, V]) Len() int {
+	t.ensureEditable()
+	return t.count
+}
+
+// Get return the value of given key in the map, and true if the key exists
+func (t *TransientMap[K, V]) Get(key K) (V, bool) {
+	t.ensureEditable()
+	return hamtGet(t.root, t.hasher(key), key)
+}
+
+// Set associates key with value in place
+func (t *TransientMap[K, V]) Set(key K, value V) *TransientMap[K, V] {
+	t.ensureEditable()
+
+	var added bool
+	t.root = hamtAssoc(t.edit, t.root, 0, t.hasher(key), key, value, &added)
+	if added {
+		t.count++
+	}
+
+	return t
+}
+
+// Delete removes the given key in place
+func (t *TransientMap[K, V]) Delete(key K) *TransientMap[K, V] {
+	t.ensureEditable()
+
+	var removed bool
+	root := hamtWithout(t.edit, t.root, 0, t.hasher(key), key, &removed)
+	if !removed {
+		return t
+	}
+	if root == nil {
+		root = &hamtNode[K, V]{edit: t.edit}
+	}
+	t.root = root
+	t.count--
+
+	return t
+}
+
+// Persistent return an immutable Map of current entries, the transient can not be used any more.
+func (t *TransientMap[K, V]) Persistent() *Map[K, V] {
+	t.ensureEditable()
+	t.edit = nil
+
+	return &Map[K, V]{count: t.count, root: t.root, hasher: t.hasher}
+}
+
+func (t *TransientMap[K, V]) ensureEditable() {
+	if t.edit == nil {
+		panic("TransientMap: used after Persistent call")
+	}
+}
+
+func hamtGet[K comparable, V any](node *hamtNode[K, V], hash uint64, key K) (V, bool) {
+	for shift := uint(0); ; shift += hamtBits {
+		if node.collision {
+			for _, slot := range node.slots {
+				if slot.key == key {
+					return slot.value, true
+				}
+			}
+			break
+		}
+
+		bit := hamtBit(hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+
+		slot := node.slots[hamtIndex(node.bitmap, bit)]
+		if slot.child == nil {
+			if slot.hash == hash && slot.key == key {
+				return slot.value, true
+			}
+			break
+		}
+		node = slot.child
+	}
+
+	var zeroValue V
+	return zeroValue, false
+}
+
+func hamtBit(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & hamtMask)
+}
+
+func hamtIndex(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// editableHamtNode return node itself if it is owned by edit, otherwise return a copy owned by edit
+func editableHamtNode[K comparable, V any](edit *editToken, node *hamtNode[K, V]) *hamtNode[K, V] {
+	if edit != nil && node.edit == edit {
+		return node
+	}
+
+	slots := make([]hamtSlot[K, V], len(node.slots), len(node.slots)+1)
+	copy(slots, node.slots)
+
+	return &hamtNode[K, V]{edit: edit, bitmap: node.bitmap, collision: node.collision, slots: slots}
+}
+
+func hamtAssoc[K comparable, V any](edit *editToken, node *hamtNode[K, V], shift uint, hash uint64, key K, value V, added *bool) *hamtNode[K, V] {
+	if node.collision {
+		result := editableHamtNode(edit, node)
+		for i, slot := range result.slots {
+			if slot.key == key {
+				result.slots[i].value = value
+				return result
+			}
+		}
+		result.slots = append(result.slots, hamtSlot[K, V]{hash: hash, key: key, value: value})
+		*added = true
+		return result
+	}
+
+	bit := hamtBit(hash, shift)
+	index := hamtIndex(node.bitmap, bit)
+
+	if node.bitmap&bit == 0 {
+		result := editableHamtNode(edit, node)
+		result.slots = append(result.slots, hamtSlot[K, V]{})
+		copy(result.slots[index+1:], result.slots[index:])
+		result.slots[index] = hamtSlot[K, V]{hash: hash, key: key, value: value}
+		result.bitmap |= bit
+		*added = true
+		return result
+	}
+
+	slot := node.slots[index]
+	result := editableHamtNode(edit, node)
+
+	switch {
+	case slot.child != nil:
+		result.slots[index].child = hamtAssoc(edit, slot.child, shift+hamtBits, hash, key, value, added)
+	case slot.hash == hash && slot.key == key:
+		result.slots[index].value = value
+	default:
+		newSlot := hamtSlot[K, V]{hash: hash, key: key, value: value}
+		result.slots[index] = hamtSlot[K, V]{child: hamtCreateNode(edit, shift+hamtBits, slot, newSlot)}
+		*added = true
+	}
+
+	return result
+}
+
+// hamtCreateNode return a node containing two leaf slots whose hashes are the same before shift
+func hamtCreateNode[K comparable, V any](edit *editToken, shift uint, a, b hamtSlot[K, V]) *hamtNode[K, V] {
+	if shift >= hamtMaxShift {
+		return &hamtNode[K, V]{edit: edit, collision: true, slots: []hamtSlot[K, V]{a, b}}
+	}
+
+	bitA, bitB := hamtBit(a.hash, shift), hamtBit(b.hash, shift)
+	if bitA == bitB {
+		child := hamtCreateNode(edit, shift+hamtBits, a, b)
+		return &hamtNode[K, V]{edit: edit, bitmap: bitA, slots: []hamtSlot[K, V]{{child: child}}}
+	}
+
+	if bitA > bitB {
+		a, b = b, a
+	}
+
+	return &hamtNode[K, V]{edit: edit, bitmap: bitA | bitB, slots: []hamtSlot[K, V]{a, b}}
+}
+
+// hamtWithout removes key from node, return nil if the node becomes empty
+func hamtWithout[K comparable, V any](edit *editToken, node *hamtNode[K, V], shift uint, hash uint64, key K, removed *bool) *hamtNode[K, V] {
+	if node.collision {
+		for i, slot := range node.slots {
+			if slot.key == key {
+				*removed = true
+				if len(node.slots) == 1 {
+					return nil
+				}
+				result := editableHamtNode(edit, node)
+				result.slots = append(result.slots[:i], result.slots[i+1:]...)
+				return result
+			}
+		}
+		return node
+	}
+
+	bit := hamtBit(hash, shift)
+	if node.bitmap&bit == 0 {
+		return node
+	}
+
+	index := hamtIndex(node.bitmap, bit)
+	slot := node.slots[index]
+
+	if slot.child != nil {
+		child := hamtWithout(edit, slot.child, shift+hamtBits, hash, key, removed)
+		if !*removed {
+			return node
+		}
+		if child != nil {
+			result := editableHamtNode(edit, node)
+			// pull a single leaf up, so a key is always stored at the shallowest level
+			if len(child.slots) == 1 && child.slots[0].child == nil {
+				result.slots[index] = child.slots[0]
+			} else {
+				result.slots[index].child = child
+			}
+			return result
+		}
+	} else if slot.hash != hash || slot.key != key {
+		return node
+	}
+
+	*removed = true
+	if len(node.slots) == 1 {
+		return nil
+	}
+
+	result := editableHamtNode(edit, node)
+	result.slots = append(result.slots[:index], result.slots[index+1:]...)
+	result.bitmap &^= bit
+
+	return result
+}
+
+func hamtIterate[K comparable, V any](node *hamtNode[K, V], iteratee func(key K, value V) bool) bool {
+	for _, slot := range node.slots {
+		if slot.child != nil {
+			if !hamtIterate(slot.child, iteratee) {
+				return false
+			}
+		} else if !iteratee(slot.key, slot.value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package datastructure
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestMap_SetGet(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap_SetGet")
+
+	m1 := NewMap[string, int]()
+	m2 := m1.Set("a", 1).Set("b", 2)
+	m3 := m2.Set("a", 10)
+
+	assert.Equal(0, m1.Len())
+	assert.Equal(2, m2.Len())
+	assert.Equal(2, m3.Len())
+
+	v, ok := m2.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+
+	v, _ = m3.Get("a")
+	assert.Equal(10, v)
+
+	_, ok = m3.Get("c")
+	assert.Equal(false, ok)
+	assert.Equal(false, m1.Contains("a"))
+}
+
+func TestMap_Delete(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap_Delete")
+
+	m := NewMap[int, string]()
+	for i := 0; i < 1000; i++ {
+		m = m.Set(i, strconv.Itoa(i))
+	}
+	full := m
+
+	for i := 0; i < 1000; i += 2 {
+		m = m.Delete(i)
+	}
+	assert.Equal(500, m.Len())
+	assert.Equal(1000, full.Len())
+	assert.Equal(m, m.Delete(0))
+
+	for i := 0; i < 1000; i++ {
+		_, ok := m.Get(i)
+		assert.Equal(i%2 == 1, ok)
+
+		v, _ := full.Get(i)
+		assert.Equal(strconv.Itoa(i), v)
+	}
+
+	keys := m.Keys()
+	sort.Ints(keys)
+	assert.Equal(500, len(keys))
+	assert.Equal(1, keys[0])
+	assert.Equal(999, keys[499])
+}
+
+func TestMap_Collision(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap_Collision")
+
+	m := NewMapWithHasher[int, int](func(key int) uint64 { return uint64(key % 3) })
+	for i := 0; i < 30; i++ {
+		m = m.Set(i, i*i)
+	}
+	assert.Equal(30, m.Len())
+
+	v, ok := m.Get(29)
+	assert.Equal(true, ok)
+	assert.Equal(841, v)
+
+	for i := 0; i < 30; i++ {
+		m = m.Delete(i)
+	}
+	assert.Equal(true, m.IsEmpty())
+	assert.Equal(0, len(m.ToMap()))
+}
+
+func TestTransientMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTransientMap")
+
+	m := NewMap[int, int]().Set(1, 1)
+
+	tm := m.Transient()
+	for i := 0; i < 500; i++ {
+		tm.Set(i, i*2)
+	}
+	tm.Delete(0).Delete(1000)
+	assert.Equal(499, tm.Len())
+
+	m2 := tm.Persistent()
+
+	v, _ := m.Get(1)
+	assert.Equal(1, v)
+	assert.Equal(1, m.Len())
+
+	v, _ = m2.Get(1)
+	assert.Equal(2, v)
+	assert.Equal(499, len(m2.ToMap()))
+	assert.Equal(499, len(m2.Values()))
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	tm.Set(1, 1)
+}
+
+func TestTransientMap_KeepsOldVersions(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTransientMap_KeepsOldVersions")
+
+	r := rand.New(rand.NewSource(1))
+
+	versions := []*Map[int, int]{NewMap[int, int]()}
+	references := []map[int]int{{}}
+
+	for i := 0; i < 50; i++ {
+		base := r.Intn(len(versions))
+
+		reference := make(map[int]int)
+		for k, v := range references[base] {
+			reference[k] = v
+		}
+
+		tr := versions[base].Transient()
+		for j := 0; j < 20; j++ {
+			key := r.Intn(100)
+			if r.Intn(4) == 0 {
+				tr.Delete(key)
+				delete(reference, key)
+			} else {
+				tr.Set(key, i)
+				reference[key] = i
+			}
+		}
+
+		versions = append(versions, tr.Persistent())
+		references = append(references, reference)
+	}
+
+	// every version still holds exactly the entries it was built with
+	for i, m := range versions {
+		assert.Equal(len(references[i]), m.Len())
+		for k, v := range references[i] {
+			value, ok := m.Get(k)
+			assert.Equal(true, ok)
+			assert.Equal(v, value)
+		}
+	}
+}
+
+func TestMap_DefaultHasher(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap_DefaultHasher")
+
+	negativeZero := math.Copysign(0, -1)
+
+	m := NewMap[float64, string]().Set(0.0, "zero")
+	value, ok := m.Get(negativeZero)
+	assert.Equal(true, ok)
+	assert.Equal("zero", value)
+
+	m = m.Set(negativeZero, "negative zero")
+	assert.Equal(1, m.Len())
+
+	type point struct {
+		x, y float64
+	}
+	pm := NewMap[point, int]().Set(point{0, 1}, 1)
+	_, ok = pm.Get(point{negativeZero, 1})
+	assert.Equal(true, ok)
+}
+
+func TestMap_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap_Iterator")
+
+	m := NewMap[int, string]()
+	for i := 0; i < 100; i++ {
+		m = m.Set(i, strconv.Itoa(i))
+	}
+
+	seen := map[int]string{}
+	iter := m.Iterator()
+	for iter.HasNext() {
+		entry, _ := iter.Next()
+		seen[entry.First] = entry.Second
+	}
+
+	assert.Equal(100, len(seen))
+	for k, v := range seen {
+		assert.Equal(strconv.Itoa(k), v)
+	}
+}
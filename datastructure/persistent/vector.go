@@ -0,0 +1,483 @@
+// Package datastructure implements some data structure. eg. list, linklist, stack, queue, tree, graph.
+package datastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Collection[any] = (*Vector[any])(nil)
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// editToken marks the nodes owned by a transient, the transient may mutate them in place.
+// It must not be zero-size, pointers to distinct zero-size values may be equal, which would let a transient
+// mutate the nodes of another one.
+type editToken struct {
+	_ byte
+}
+
+type vectorNode[T any] struct {
+	edit     *editToken
+	children []*vectorNode[T]
+	values   []T
+}
+
+// Vector is a persistent (immutable) vector implemented by a bit-partitioned trie with a tail buffer.
+// Set, Append and Pop return a new version which shares structure with the old one, the old version is never changed,
+// so a vector can be passed between goroutines without copying or locking.
+type Vector[T any] struct {
+	count int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+}
+
+// NewVector return a Vector pointer containing the given items
+func NewVector[T any](items ...T) *Vector[T] {
+	t := emptyVector[T]().Transient()
+	for _, item := range items {
+		t.Append(item)
+	}
+	return t.Persistent()
+}
+
+func emptyVector[T any]() *Vector[T] {
+	return &Vector[T]{
+		shift: vectorBits,
+		root:  &vectorNode[T]{children: make([]*vectorNode[T], vectorWidth)},
+		tail:  []T{},
+	}
+}
+
+// Len return the number of items in the vector
+func (v *Vector[T]) Len() int {
+	return v.count
+}
+
+// Size return the number of items in the vector, it is the same as Len
+func (v *Vector[T]) Size() int {
+	return v.count
+}
+
+// IsEmpty checks if the vector is empty or not
+func (v *Vector[T]) IsEmpty() bool {
+	return v.count == 0
+}
+
+// Get return the item at index
+func (v *Vector[T]) Get(index int) (T, error) {
+	if index < 0 || index >= v.count {
+		var zeroValue T
+		return zeroValue, errors.New("index out of range")
+	}
+
+	return vectorLeaf(v.count, v.shift, v.root, v.tail, index)[index&vectorMask], nil
+}
+
+// Set return a new vector whose item at index is replaced by value, index could be Len() to append value
+func (v *Vector[T]) Set(index int, value T) (*Vector[T], error) {
+	if index < 0 || index > v.count {
+		return v, errors.New("index out of range")
+	}
+	if index == v.count {
+		return v.Append(value), nil
+	}
+
+	if index >= vectorTailOffset(v.count) {
+		tail := make([]T, len(v.tail))
+		copy(tail, v.tail)
+		tail[index&vectorMask] = value
+
+		return &Vector[T]{count: v.count, shift: v.shift, root: v.root, tail: tail}, nil
+	}
+
+	root := vectorAssoc(nil, v.shift, v.root, index, value)
+
+	return &Vector[T]{count: v.count, shift: v.shift, root: root, tail: v.tail}, nil
+}
+
+// Append return a new vector with value added to the end
+func (v *Vector[T]) Append(value T) *Vector[T] {
+	if v.count-vectorTailOffset(v.count) < vectorWidth {
+		tail := make([]T, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = value
+
+		return &Vector[T]{count: v.count + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	root, shift := vectorPushTail(nil, v.count, v.shift, v.root, &vectorNode[T]{values: v.tail})
+
+	return &Vector[T]{count: v.count + 1, shift: shift, root: root, tail: []T{value}}
+}
+
+// Pop return a new vector without the last item, and the removed item.
+// if the vector is empty, return itself, zero value and false
+func (v *Vector[T]) Pop() (*Vector[T], T, bool) {
+	var last T
+	if v.count == 0 {
+		return v, last, false
+	}
+
+	last, _ = v.Get(v.count - 1)
+	if v.count == 1 {
+		return emptyVector[T](), last, true
+	}
+
+	if v.count-vectorTailOffset(v.count) > 1 {
+		tail := make([]T, len(v.tail)-1)
+		copy(tail, v.tail)
+
+		return &Vector[T]{count: v.count - 1, shift: v.shift, root: v.root, tail: tail}, last, true
+	}
+
+	root, shift, tail := vectorPopTail(nil, v.count, v.shift, v.root)
+
+	return &Vector[T]{count: v.count - 1, shift: shift, root: root, tail: tail}, last, true
+}
+
+// Iterate executes iteratee funcation for every item in order, iteration stops if iteratee returns false
+func (v *Vector[T]) Iterate(iteratee func(index int, item T) bool) {
+	for i := 0; i < v.count; i += vectorWidth {
+		leaf := vectorLeaf(v.count, v.shift, v.root, v.tail, i)
+		for j, item := range leaf {
+			if !iteratee(i+j, item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice return a slice of all items in the vector
+func (v *Vector[T]) ToSlice() []T {
+	result := make([]T, 0, v.count)
+	v.Iterate(func(_ int, item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// Values return a slice of all items in the vector, it is the same as ToSlice
+func (v *Vector[T]) Values() []T {
+	return v.ToSlice()
+}
+
+// Contains checks if the value is in the vector or not
+func (v *Vector[T]) Contains(value T) bool {
+	found := false
+	v.Iterate(func(_ int, item T) bool {
+		found = reflect.DeepEqual(item, value)
+		return !found
+	})
+	return found
+}
+
+// Iterator returns an iterator over all items in order.
+// the vector is immutable, so the iterator is not affected by later updates.
+func (v *Vector[T]) Iterator() iterator.Iterator[T] {
+	return &vectorIterator[T]{vector: v}
+}
+
+// String returns all items of the vector, eg. [1 2 3]
+func (v *Vector[T]) String() string {
+	return fmt.Sprint(v.ToSlice())
+}
+
+// MarshalJSON encodes the vector as a json array
+func (v *Vector[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ToSlice())
+}
+
+type vectorIterator[T any] struct {
+	vector *Vector[T]
+	leaf   []T
+	index  int
+}
+
+func (iter *vectorIterator[T]) HasNext() bool {
+	return iter.index < iter.vector.count
+}
+
+func (iter *vectorIterator[T]) Next() (T, bool) {
+	if !iter.HasNext() {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	v := iter.vector
+	if iter.index%vectorWidth == 0 {
+		iter.leaf = vectorLeaf(v.count, v.shift, v.root, v.tail, iter.index)
+	}
+	item := iter.leaf[iter.index%vectorWidth]
+	iter.index++
+
+	return item, true
+}
+
+// Transient return a mutable copy of the vector for batch updates,
+// it shares structure with the vector and copies nodes lazily on the first write.
+func (v *Vector[T]) Transient() *TransientVector[T] {
+	tail := make([]T, len(v.tail), vectorWidth)
+	copy(tail, v.tail)
+
+	return &TransientVector[T]{
+		edit:  &editToken{},
+		count: v.count,
+		shift: v.shift,
+		root:  v.root,
+		tail:  tail,
+	}
+}
+
+// TransientVector is a mutable version of Vector for efficient batch updates.
+// it is not safe for concurrent use, and can not be used after calling Persistent.
+type TransientVector[T any] struct {
+	edit  *editToken
+	count int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+}
+
+// Len return the number of items in the vector
+func (t *TransientVector[T]) Len() int {
+	t.ensureEditable()
+	return t.count
+}
+
+// Get return the item at index
+func (t *TransientVector[T]) Get(index int) (T, error) {
+	t.ensureEditable()
+	if index < 0 || index >= t.count {
+		var zeroValue T
+		return zeroValue, errors.New("index out of range")
+	}
+
+	return vectorLeaf(t.count, t.shift, t.root, t.tail, index)[index&vectorMask], nil
+}
+
+// Set replaces the item at index with value in place, index could be Len() to append value
+func (t *TransientVector[T]) Set(index int, value T) error {
+	t.ensureEditable()
+	if index < 0 || index > t.count {
+		return errors.New("index out of range")
+	}
+	if index == t.count {
+		t.Append(value)
+		return nil
+	}
+
+	if index >= vectorTailOffset(t.count) {
+		t.tail[index&vectorMask] = value
+		return nil
+	}
+
+	t.root = vectorAssoc(t.edit, t.shift, t.root, index, value)
+
+	return nil
+}
+
+// Append adds value to the end of the vector in place
+func (t *TransientVector[T]) Append(value T) *TransientVector[T] {
+	t.ensureEditable()
+
+	if t.count-vectorTailOffset(t.count) < vectorWidth {
+		t.tail = append(t.tail, value)
+		t.count++
+		return t
+	}
+
+	tailNode := &vectorNode[T]{edit: t.edit, values: t.tail}
+	t.root, t.shift = vectorPushTail(t.edit, t.count, t.shift, t.root, tailNode)
+
+	t.tail = make([]T, 1, vectorWidth)
+	t.tail[0] = value
+	t.count++
+
+	return t
+}
+
+// Pop removes the last item of the vector in place and returns it
+func (t *TransientVector[T]) Pop() (T, bool) {
+	t.ensureEditable()
+
+	var last T
+	if t.count == 0 {
+		return last, false
+	}
+
+	last, _ = t.Get(t.count - 1)
+	if t.count-vectorTailOffset(t.count) > 1 || t.count == 1 {
+		var zeroValue T
+		t.tail[len(t.tail)-1] = zeroValue
+		t.tail = t.tail[:len(t.tail)-1]
+		t.count--
+		return last, true
+	}
+
+	var tail []T
+	t.root, t.shift, tail = vectorPopTail(t.edit, t.count, t.shift, t.root)
+	t.tail = make([]T, len(tail), vectorWidth)
+	copy(t.tail, tail)
+	t.count--
+
+	return last, true
+}
+
+// Persistent return an immutable Vector of current items, the transient can not be used any more.
+func (t *TransientVector[T]) Persistent() *Vector[T] {
+	t.ensureEditable()
+	t.edit = nil
+
+	tail := make([]T, len(t.tail))
+	copy(tail, t.tail)
+
+	return &Vector[T]{count: t.count, shift: t.shift, root: t.root, tail: tail}
+}
+
+func (t *TransientVector[T]) ensureEditable() {
+	if t.edit == nil {
+		panic("TransientVector: used after Persistent call")
+	}
+}
+
+func vectorTailOffset(count int) int {
+	if count < vectorWidth {
+		return 0
+	}
+	return ((count - 1) >> vectorBits) << vectorBits
+}
+
+// vectorLeaf return the slice of values which contains the item at index
+func vectorLeaf[T any](count int, shift uint, root *vectorNode[T], tail []T, index int) []T {
+	if index >= vectorTailOffset(count) {
+		return tail
+	}
+
+	node := root
+	for level := shift; level > 0; level -= vectorBits {
+		node = node.children[(index>>level)&vectorMask]
+	}
+
+	return node.values
+}
+
+// editableVectorNode return node itself if it is owned by edit, otherwise return a copy owned by edit
+func editableVectorNode[T any](edit *editToken, node *vectorNode[T]) *vectorNode[T] {
+	if edit != nil && node.edit == edit {
+		return node
+	}
+
+	clone := &vectorNode[T]{edit: edit}
+	if node.children != nil {
+		clone.children = make([]*vectorNode[T], vectorWidth)
+		copy(clone.children, node.children)
+	}
+	if node.values != nil {
+		clone.values = make([]T, len(node.values))
+		copy(clone.values, node.values)
+	}
+
+	return clone
+}
+
+func vectorAssoc[T any](edit *editToken, level uint, node *vectorNode[T], index int, value T) *vectorNode[T] {
+	result := editableVectorNode(edit, node)
+	if level == 0 {
+		result.values[index&vectorMask] = value
+		return result
+	}
+
+	subIndex := (index >> level) & vectorMask
+	result.children[subIndex] = vectorAssoc(edit, level-vectorBits, node.children[subIndex], index, value)
+
+	return result
+}
+
+// vectorPushTail moves the full tail node into the trie, a new level is added when the root is full
+func vectorPushTail[T any](edit *editToken, count int, shift uint, root, tailNode *vectorNode[T]) (*vectorNode[T], uint) {
+	if (count >> vectorBits) > (1 << shift) {
+		newRoot := &vectorNode[T]{edit: edit, children: make([]*vectorNode[T], vectorWidth)}
+		newRoot.children[0] = root
+		newRoot.children[1] = vectorNewPath(edit, shift, tailNode)
+		return newRoot, shift + vectorBits
+	}
+
+	return vectorPushTailAt(edit, count, shift, root, tailNode), shift
+}
+
+func vectorPushTailAt[T any](edit *editToken, count int, level uint, parent, tailNode *vectorNode[T]) *vectorNode[T] {
+	result := editableVectorNode(edit, parent)
+	subIndex := ((count - 1) >> level) & vectorMask
+
+	if level == vectorBits {
+		result.children[subIndex] = tailNode
+	} else if child := parent.children[subIndex]; child != nil {
+		result.children[subIndex] = vectorPushTailAt(edit, count, level-vectorBits, child, tailNode)
+	} else {
+		result.children[subIndex] = vectorNewPath(edit, level-vectorBits, tailNode)
+	}
+
+	return result
+}
+
+func vectorNewPath[T any](edit *editToken, level uint, node *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return node
+	}
+
+	result := &vectorNode[T]{edit: edit, children: make([]*vectorNode[T], vectorWidth)}
+	result.children[0] = vectorNewPath(edit, level-vectorBits, node)
+
+	return result
+}
+
+// vectorPopTail removes the last leaf from the trie and return it as the new tail, a level is removed when the root has only one child
+func vectorPopTail[T any](edit *editToken, count int, shift uint, root *vectorNode[T]) (*vectorNode[T], uint, []T) {
+	tail := vectorLeaf(count, shift, root, nil, count-2)
+
+	newRoot := vectorPopTailAt(edit, count, shift, root)
+	if newRoot == nil {
+		newRoot = &vectorNode[T]{edit: edit, children: make([]*vectorNode[T], vectorWidth)}
+	}
+	if shift > vectorBits && newRoot.children[1] == nil {
+		return newRoot.children[0], shift - vectorBits, tail
+	}
+
+	return newRoot, shift, tail
+}
+
+func vectorPopTailAt[T any](edit *editToken, count int, level uint, node *vectorNode[T]) *vectorNode[T] {
+	subIndex := ((count - 2) >> level) & vectorMask
+
+	if level > vectorBits {
+		child := vectorPopTailAt(edit, count, level-vectorBits, node.children[subIndex])
+		if child == nil && subIndex == 0 {
+			return nil
+		}
+
+		result := editableVectorNode(edit, node)
+		result.children[subIndex] = child
+		return result
+	}
+
+	if subIndex == 0 {
+		return nil
+	}
+
+	result := editableVectorNode(edit, node)
+	result.children[subIndex] = nil
+
+	return result
+}
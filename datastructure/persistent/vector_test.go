@@ -0,0 +1,184 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestVector_Append(t *testing.T) {
+	assert := internal.NewAssert(t, "TestVector_Append")
+
+	v := NewVector[int]()
+	versions := []*Vector[int]{v}
+	for i := 0; i < 2000; i++ {
+		v = v.Append(i)
+		versions = append(versions, v)
+	}
+
+	assert.Equal(2000, v.Len())
+	for i, version := range versions {
+		assert.Equal(i, version.Len())
+	}
+
+	item, err := v.Get(1234)
+	assert.IsNil(err)
+	assert.Equal(1234, item)
+
+	_, err = v.Get(2000)
+	assert.IsNotNil(err)
+
+	data := versions[100].ToSlice()
+	assert.Equal(100, len(data))
+	assert.Equal(99, data[99])
+}
+
+func TestVector_Set(t *testing.T) {
+	assert := internal.NewAssert(t, "TestVector_Set")
+
+	v1 := NewVector[int]()
+	for i := 0; i < 100; i++ {
+		v1 = v1.Append(i)
+	}
+
+	v2, err := v1.Set(10, -10)
+	assert.IsNil(err)
+	v3, _ := v2.Set(99, -99)
+
+	item, _ := v1.Get(10)
+	assert.Equal(10, item)
+	item, _ = v2.Get(10)
+	assert.Equal(-10, item)
+	item, _ = v2.Get(99)
+	assert.Equal(99, item)
+	item, _ = v3.Get(99)
+	assert.Equal(-99, item)
+
+	v4, _ := v3.Set(100, 100)
+	assert.Equal(101, v4.Len())
+	assert.Equal(100, v3.Len())
+
+	_, err = v4.Set(-1, 0)
+	assert.IsNotNil(err)
+}
+
+func TestVector_Pop(t *testing.T) {
+	assert := internal.NewAssert(t, "TestVector_Pop")
+
+	items := make([]int, 1100)
+	for i := range items {
+		items[i] = i
+	}
+	full := NewVector(items...)
+
+	v := full
+	for i := len(items) - 1; i >= 0; i-- {
+		var last int
+		var ok bool
+		v, last, ok = v.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(i, last)
+		assert.Equal(i, v.Len())
+	}
+
+	_, _, ok := v.Pop()
+	assert.Equal(false, ok)
+	assert.Equal(items, full.ToSlice())
+
+	v = v.Append(1)
+	assert.Equal([]int{1}, v.ToSlice())
+}
+
+func TestTransientVector(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTransientVector")
+
+	v := NewVector(1, 2, 3)
+
+	tv := v.Transient()
+	for i := 4; i <= 1000; i++ {
+		tv.Append(i)
+	}
+	assert.IsNil(tv.Set(0, 100))
+	assert.IsNil(tv.Set(500, -500))
+	last, ok := tv.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(1000, last)
+
+	v2 := tv.Persistent()
+
+	assert.Equal([]int{1, 2, 3}, v.ToSlice())
+	assert.Equal(999, v2.Len())
+
+	item, _ := v2.Get(0)
+	assert.Equal(100, item)
+	item, _ = v2.Get(500)
+	assert.Equal(-500, item)
+	item, _ = v2.Get(998)
+	assert.Equal(999, item)
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	tv.Append(1)
+}
+
+func TestVector_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestVector_Iterator")
+
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	v := NewVector(items...)
+
+	result := []int{}
+	iter := v.Iterator()
+	for iter.HasNext() {
+		item, _ := iter.Next()
+		result = append(result, item)
+	}
+	assert.Equal(items, result)
+
+	assert.Equal(true, v.Contains(99))
+	assert.Equal(false, v.Contains(100))
+}
+
+func TestVector_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestVector_JSON")
+
+	v := NewVector(1, 2, 3)
+	data, err := v.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[1,2,3]", string(data))
+	assert.Equal("[1 2 3]", v.String())
+}
+
+func TestTransientVector_KeepsOldVersions(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTransientVector_KeepsOldVersions")
+
+	v1 := NewVector(make([]int, 100)...)
+
+	tr := v1.Transient()
+	tr.Set(0, 42)
+	v2 := tr.Persistent()
+
+	// a later transient session must not edit the nodes owned by an earlier one
+	tr = v2.Transient()
+	tr.Set(1, 43)
+	v3 := tr.Persistent()
+
+	tr = v1.Transient()
+	tr.Set(2, 44)
+	tr.Persistent()
+
+	v1Value, _ := v1.Get(0)
+	assert.Equal(0, v1Value)
+
+	v2Value, _ := v2.Get(1)
+	assert.Equal(0, v2Value)
+
+	v3Value, _ := v3.Get(0)
+	assert.Equal(42, v3Value)
+	v3Value, _ = v3.Get(2)
+	assert.Equal(0, v3Value)
+}
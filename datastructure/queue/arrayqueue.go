@@ -1,10 +1,18 @@
 package datastructure
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Queue[any] = (*ArrayQueue[any])(nil)
+
 // ArrayQueue implements queue with slice
 type ArrayQueue[T any] struct {
 	items    []T
@@ -109,6 +117,8 @@ func (q *ArrayQueue[T]) Contain(value T) bool {
 }
 
 // Print queue data
+//
+// Deprecated: use String instead.
 func (q *ArrayQueue[T]) Print() {
 	info := "["
 	for i := q.head; i < q.tail; i++ {
@@ -117,3 +127,65 @@ func (q *ArrayQueue[T]) Print() {
 	info += "]"
 	fmt.Println(info)
 }
+
+// Offer put element into queue, returns false if the queue is full
+func (q *ArrayQueue[T]) Offer(value T) bool {
+	return q.Enqueue(value)
+}
+
+// Poll remove head element of queue and return it, if queue is empty, return zero value and false
+func (q *ArrayQueue[T]) Poll() (T, bool) {
+	return q.Dequeue()
+}
+
+// Peek return head element of queue, if queue is empty, return zero value and false
+func (q *ArrayQueue[T]) Peek() (T, bool) {
+	if q.head == q.tail {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return q.items[q.head], true
+}
+
+// Values return slice of queue data, from head to tail
+func (q *ArrayQueue[T]) Values() []T {
+	return q.Data()
+}
+
+// Contains checks if the value is in queue or not
+func (q *ArrayQueue[T]) Contains(value T) bool {
+	return internal.ContainsValue(q.Data(), value)
+}
+
+// Iterator returns an iterator over a snapshot of the queue, from head to tail.
+func (q *ArrayQueue[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(q.Data())
+}
+
+// String returns the queue data from head to tail, eg. [1 2 3]
+func (q *ArrayQueue[T]) String() string {
+	return internal.FormatValues(q.Data())
+}
+
+// MarshalJSON encodes the queue as a json array, from head to tail
+func (q *ArrayQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from head to tail, and replaces the queue elements with them
+func (q *ArrayQueue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) > q.capacity {
+		return errors.New("queue capacity is not enough")
+	}
+
+	q.Clear()
+	for _, v := range values {
+		q.Enqueue(v)
+	}
+
+	return nil
+}
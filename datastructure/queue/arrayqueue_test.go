@@ -1,9 +1,11 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
 func TestArrayQueue_Enqueue(t *testing.T) {
@@ -104,3 +106,39 @@ func TestArrayQueue_IsFull(t *testing.T) {
 
 	assert.Equal(true, queue.IsFull())
 }
+
+func TestArrayQueue_OfferPoll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayQueue_OfferPoll")
+
+	queue := NewArrayQueue[int](5)
+	_, ok := queue.Peek()
+	assert.Equal(false, ok)
+
+	assert.Equal(true, queue.Offer(1))
+	assert.Equal(true, queue.Offer(2))
+	assert.Equal(true, queue.Offer(3))
+
+	head, ok := queue.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	head, ok = queue.Poll()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	assert.Equal("[2 3]", queue.String())
+	assert.Equal(true, queue.Contains(3))
+	assert.Equal(false, queue.Contains(1))
+	assert.Equal([]int{2, 3}, iterator.ToSlice(queue.Iterator()))
+
+	data, err := json.Marshal(queue)
+	assert.IsNil(err)
+	assert.Equal("[2,3]", string(data))
+
+	other := NewArrayQueue[int](2)
+	other.Enqueue(4)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal([]int{2, 3}, other.Values())
+	assert.IsNotNil(json.Unmarshal([]byte("[1,2,3]"), other))
+	assert.Equal([]int{2, 3}, other.Values())
+}
@@ -1,11 +1,18 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Queue[any] = (*CircularQueue[any])(nil)
+
 // CircularQueue implements circular queue with slice,
 // last index of CircularQueue don't contain value, so acturl capacity is capacity - 1
 type CircularQueue[T any] struct {
@@ -113,6 +120,78 @@ func (q *CircularQueue[T]) Contain(value T) bool {
 }
 
 // Print queue data
+//
+// Deprecated: use String instead.
 func (q *CircularQueue[T]) Print() {
 	fmt.Printf("%+v\n", q)
 }
+
+// Offer put element into queue, returns false if the queue is full
+func (q *CircularQueue[T]) Offer(value T) bool {
+	return q.Enqueue(value) == nil
+}
+
+// Poll remove head element of queue and return it, if queue is empty, return zero value and false
+func (q *CircularQueue[T]) Poll() (T, bool) {
+	item, err := q.Dequeue()
+	if err != nil {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return *item, true
+}
+
+// Peek return head element of queue, if queue is empty, return zero value and false
+func (q *CircularQueue[T]) Peek() (T, bool) {
+	if q.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return q.data[q.front], true
+}
+
+// Values return slice of queue data, from head to tail
+func (q *CircularQueue[T]) Values() []T {
+	data := q.Data()
+	values := make([]T, len(data))
+	copy(values, data)
+	return values
+}
+
+// Contains checks if the value is in queue or not
+func (q *CircularQueue[T]) Contains(value T) bool {
+	return internal.ContainsValue(q.Data(), value)
+}
+
+// Iterator returns an iterator over a snapshot of the queue, from head to tail.
+func (q *CircularQueue[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(q.Data())
+}
+
+// String returns the queue data from head to tail, eg. [1 2 3]
+func (q *CircularQueue[T]) String() string {
+	return internal.FormatValues(q.Data())
+}
+
+// MarshalJSON encodes the queue as a json array, from head to tail
+func (q *CircularQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from head to tail, and replaces the queue elements with them
+func (q *CircularQueue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) > 0 && len(values) >= q.capacity {
+		return errors.New("queue capacity is not enough")
+	}
+
+	q.data = make([]T, q.capacity)
+	copy(q.data, values)
+	q.front = 0
+	q.rear = len(values)
+
+	return nil
+}
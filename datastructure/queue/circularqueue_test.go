@@ -1,9 +1,11 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
 func TestCircularQueue_Enqueue(t *testing.T) {
@@ -143,3 +145,40 @@ func TestCircularQueue_Data(t *testing.T) {
 
 	assert.Equal([]int{1, 2}, queue.Data())
 }
+
+func TestCircularQueue_OfferPoll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircularQueue_OfferPoll")
+
+	queue := NewCircularQueue[int](6)
+	_, ok := queue.Peek()
+	assert.Equal(false, ok)
+
+	assert.Equal(true, queue.Offer(1))
+	assert.Equal(true, queue.Offer(2))
+	assert.Equal(true, queue.Offer(3))
+
+	head, ok := queue.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	head, ok = queue.Poll()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	assert.Equal("[2 3]", queue.String())
+	assert.Equal(true, queue.Contains(3))
+	assert.Equal(false, queue.Contains(1))
+	assert.Equal([]int{2, 3}, iterator.ToSlice(queue.Iterator()))
+
+	data, err := json.Marshal(queue)
+	assert.IsNil(err)
+	assert.Equal("[2,3]", string(data))
+
+	other := NewCircularQueue[int](3)
+	other.Enqueue(4)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal([]int{2, 3}, other.Values())
+	assert.Equal(true, other.IsFull())
+	assert.IsNotNil(json.Unmarshal([]byte("[1,2,3]"), other))
+	assert.Equal([]int{2, 3}, other.Values())
+}
@@ -1,13 +1,18 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Queue[any] = (*LinkedQueue[any])(nil)
+
 // LinkedQueue implements queue with link list
 type LinkedQueue[T any] struct {
 	head   *datastructure.QueueNode[T]
@@ -93,6 +98,8 @@ func (q *LinkedQueue[T]) Clear() {
 }
 
 // Print all nodes info of queue link
+//
+// Deprecated: use String instead.
 func (q *LinkedQueue[T]) Print() {
 	current := q.head
 	info := "[ "
@@ -115,3 +122,75 @@ func (q *LinkedQueue[T]) Contain(value T) bool {
 	}
 	return false
 }
+
+// Offer put element into queue, it always returns true
+func (q *LinkedQueue[T]) Offer(value T) bool {
+	q.Enqueue(value)
+	return true
+}
+
+// Poll remove head element of queue and return it, if queue is empty, return zero value and false
+func (q *LinkedQueue[T]) Poll() (T, bool) {
+	if q.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	head := q.head
+	q.head = q.head.Next
+	if q.head == nil {
+		q.tail = nil
+	}
+	q.length--
+
+	return head.Value, true
+}
+
+// Peek return head element of queue, if queue is empty, return zero value and false
+func (q *LinkedQueue[T]) Peek() (T, bool) {
+	if q.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return q.head.Value, true
+}
+
+// Values return slice of queue data, from head to tail
+func (q *LinkedQueue[T]) Values() []T {
+	return q.Data()
+}
+
+// Contains checks if the value is in queue or not
+func (q *LinkedQueue[T]) Contains(value T) bool {
+	return internal.ContainsValue(q.Data(), value)
+}
+
+// Iterator returns an iterator over a snapshot of the queue, from head to tail.
+func (q *LinkedQueue[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(q.Data())
+}
+
+// String returns the queue data from head to tail, eg. [1 2 3]
+func (q *LinkedQueue[T]) String() string {
+	return internal.FormatValues(q.Data())
+}
+
+// MarshalJSON encodes the queue as a json array, from head to tail
+func (q *LinkedQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from head to tail, and replaces the queue elements with them
+func (q *LinkedQueue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	q.Clear()
+	for _, v := range values {
+		q.Enqueue(v)
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
 func TestLinkedQueue_Enqueue(t *testing.T) {
@@ -93,3 +95,44 @@ func TestLinkedQueue_Contain(t *testing.T) {
 	assert.Equal(true, queue.Contain(1))
 	assert.Equal(false, queue.Contain(4))
 }
+
+func TestLinkedQueue_OfferPoll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedQueue_OfferPoll")
+
+	queue := NewLinkedQueue[int]()
+	_, ok := queue.Peek()
+	assert.Equal(false, ok)
+
+	assert.Equal(true, queue.Offer(1))
+	assert.Equal(true, queue.Offer(2))
+	assert.Equal(true, queue.Offer(3))
+
+	head, ok := queue.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	head, ok = queue.Poll()
+	assert.Equal(true, ok)
+	assert.Equal(1, head)
+
+	assert.Equal("[2 3]", queue.String())
+	assert.Equal(true, queue.Contains(3))
+	assert.Equal(false, queue.Contains(1))
+	assert.Equal([]int{2, 3}, iterator.ToSlice(queue.Iterator()))
+
+	data, err := json.Marshal(queue)
+	assert.IsNil(err)
+	assert.Equal("[2,3]", string(data))
+}
+
+func TestLinkedQueue_UnmarshalJSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedQueue_UnmarshalJSON")
+
+	queue := NewLinkedQueue[int]()
+	queue.Enqueue(0)
+	assert.IsNil(json.Unmarshal([]byte("[1,2,3]"), queue))
+
+	head, _ := queue.Poll()
+	assert.Equal(1, head)
+	assert.Equal([]int{2, 3}, queue.Values())
+}
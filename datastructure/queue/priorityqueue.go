@@ -1,11 +1,17 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 	"github.com/serialt/lancet/lancetconstraints"
 )
 
+var _ datastructure.Queue[any] = (*PriorityQueue[any])(nil)
+
 // PriorityQueue is a priority queue implemented by binary heap tree
 // type T should implements Compare function in lancetconstraints.Comparator interface.
 type PriorityQueue[T any] struct {
@@ -79,6 +85,79 @@ func (q *PriorityQueue[T]) Dequeue() (T, bool) {
 	return max, true
 }
 
+// Clear the queue data
+func (q *PriorityQueue[T]) Clear() {
+	q.items = make([]T, len(q.items))
+	q.size = 0
+}
+
+// Offer insert value into queue, returns false if the queue is full
+func (q *PriorityQueue[T]) Offer(value T) bool {
+	return q.Enqueue(value) == nil
+}
+
+// Poll delete and return max value in queue, if queue is empty, return zero value and false
+func (q *PriorityQueue[T]) Poll() (T, bool) {
+	return q.Dequeue()
+}
+
+// Peek return max value in queue, if queue is empty, return zero value and false
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if q.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return q.items[1], true
+}
+
+// Values return a slice of queue data, in heap order
+func (q *PriorityQueue[T]) Values() []T {
+	return q.Data()
+}
+
+// Contains checks if the value is in queue or not
+func (q *PriorityQueue[T]) Contains(value T) bool {
+	return internal.ContainsValue(q.Data(), value)
+}
+
+// Iterator returns an iterator over a snapshot of the queue, in heap order.
+func (q *PriorityQueue[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(q.Data())
+}
+
+// String returns the queue data in heap order, eg. [3 1 2]
+func (q *PriorityQueue[T]) String() string {
+	return internal.FormatValues(q.Data())
+}
+
+// MarshalJSON encodes the queue as a json array, in heap order
+func (q *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Data())
+}
+
+// UnmarshalJSON decodes a json array and replaces the queue elements with them,
+// the queue should be created by NewPriorityQueue first, so it has a comparator.
+func (q *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	if q.comparator == nil {
+		return errors.New("PriorityQueue: comparator is nil")
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) > len(q.items)-1 {
+		return errors.New("queue capacity is not enough")
+	}
+
+	q.Clear()
+	for _, v := range values {
+		q.Enqueue(v)
+	}
+
+	return nil
+}
+
 // swim when child's key is larger than parent's key, exchange them.
 func (q *PriorityQueue[T]) swim(index int) {
 	for index > 1 && q.comparator.Compare(q.items[index/2], q.items[index]) < 0 {
@@ -1,6 +1,7 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
@@ -68,3 +69,50 @@ func TestPriorityQueue_Dequeue(t *testing.T) {
 	assert.Equal(true, ok)
 	assert.Equal(3, val)
 }
+
+func TestPriorityQueue_OfferPoll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPriorityQueue_OfferPoll")
+
+	pq := NewPriorityQueue[int](3, &intComparator{})
+	assert.Equal(true, pq.Offer(1))
+	assert.Equal(true, pq.Offer(3))
+	assert.Equal(true, pq.Offer(2))
+	assert.Equal(false, pq.Offer(4))
+
+	max, ok := pq.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(3, max)
+	assert.Equal(true, pq.Contains(2))
+	assert.Equal("[3 1 2]", pq.String())
+
+	max, _ = pq.Poll()
+	assert.Equal(3, max)
+
+	pq.Clear()
+	assert.Equal(true, pq.IsEmpty())
+	_, ok = pq.Peek()
+	assert.Equal(false, ok)
+}
+
+func TestPriorityQueue_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPriorityQueue_JSON")
+
+	queue := NewPriorityQueue[int](3, &intComparator{})
+	queue.Enqueue(1)
+	queue.Enqueue(3)
+	queue.Enqueue(2)
+
+	data, err := json.Marshal(queue)
+	assert.IsNil(err)
+
+	other := NewPriorityQueue[int](3, &intComparator{})
+	other.Enqueue(10)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal(queue.Data(), other.Data())
+
+	max, _ := other.Dequeue()
+	assert.Equal(3, max)
+
+	assert.IsNotNil(json.Unmarshal([]byte("[1,2,3,4]"), other))
+	assert.IsNotNil(json.Unmarshal(data, &PriorityQueue[int]{}))
+}
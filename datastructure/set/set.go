@@ -1,5 +1,15 @@
 package datastructure
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Collection[int] = Set[int](nil)
+
 // Set is a data container, like slice, but element of set is not duplicate
 type Set[T comparable] map[T]struct{}
 
@@ -193,3 +203,43 @@ func (s Set[T]) Pop() (v T, ok bool) {
 
 	return v, false
 }
+
+// Contains checks if item is in set or not
+func (s Set[T]) Contains(item T) bool {
+	return s.Contain(item)
+}
+
+// Clear remove all elements of set
+func (s Set[T]) Clear() {
+	for v := range s {
+		delete(s, v)
+	}
+}
+
+// Iterator returns an iterator over a snapshot of set elements (random order)
+func (s Set[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Values())
+}
+
+// String returns the elements of set (random order), eg. [1 2 3]
+func (s Set[T]) String() string {
+	return fmt.Sprint(s.Values())
+}
+
+// MarshalJSON encodes set as a json array (random order)
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values())
+}
+
+// UnmarshalJSON decodes a json array and replaces the set elements with them
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*s = make(Set[T], len(values))
+	s.Add(values...)
+
+	return nil
+}
@@ -229,3 +229,26 @@ func TestEachWithBreak(t *testing.T) {
 // 	assert.Equal(3, val)
 // 	assert.Equal(true, ok)
 // }
+
+func TestSet_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSet_JSON")
+
+	set := NewSet(1)
+	data, err := set.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[1]", string(data))
+	assert.Equal("[1]", set.String())
+
+	var decoded Set[int]
+	err = decoded.UnmarshalJSON([]byte("[1,2,2,3]"))
+	assert.IsNil(err)
+	assert.Equal(true, decoded.Equal(NewSet(1, 2, 3)))
+	assert.Equal(true, decoded.Contains(3))
+
+	err = decoded.UnmarshalJSON([]byte("[4]"))
+	assert.IsNil(err)
+	assert.Equal(true, decoded.Equal(NewSet(4)))
+
+	decoded.Clear()
+	assert.Equal(true, decoded.IsEmpty())
+}
@@ -1,6 +1,15 @@
 package datastructure
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Stack[any] = (*ArrayStack[any])(nil)
 
 // ArrayStack implements stack with slice
 type ArrayStack[T any] struct {
@@ -18,6 +27,13 @@ func (s *ArrayStack[T]) Data() []T {
 	return s.data
 }
 
+// Values return stack data, from top to bottom
+func (s *ArrayStack[T]) Values() []T {
+	values := make([]T, len(s.data))
+	copy(values, s.data)
+	return values
+}
+
 // Size return length of stack data
 func (s *ArrayStack[T]) Size() int {
 	return s.length
@@ -47,12 +63,36 @@ func (s *ArrayStack[T]) Pop() (*T, error) {
 	return &topItem, nil
 }
 
-// Peak return the top element of stack
+// Poll delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *ArrayStack[T]) Poll() (T, bool) {
+	topItem, err := s.Pop()
+	if err != nil {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return *topItem, true
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *ArrayStack[T]) Peek() (T, bool) {
+	if s.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return s.data[0], true
+}
+
+// Peak return a copy of the top element of stack
+//
+// Deprecated: use Peek instead.
 func (s *ArrayStack[T]) Peak() (*T, error) {
 	if s.IsEmpty() {
 		return nil, errors.New("stack is empty")
 	}
-	return &s.data[0], nil
+
+	topItem := s.data[0]
+
+	return &topItem, nil
 }
 
 // Clear the stack data
@@ -60,3 +100,31 @@ func (s *ArrayStack[T]) Clear() {
 	s.data = []T{}
 	s.length = 0
 }
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *ArrayStack[T]) Iterator() iterator.Iterator[T] {
+	data := make([]T, s.length)
+	copy(data, s.data)
+
+	return iterator.FromSlice(data)
+}
+
+// Contains checks if the value is in stack or not
+func (s *ArrayStack[T]) Contains(value T) bool {
+	return internal.ContainsValue(s.data, value)
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *ArrayStack[T]) String() string {
+	return internal.FormatValues(s.data)
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *ArrayStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.data)
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *ArrayStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
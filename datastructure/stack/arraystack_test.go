@@ -1,9 +1,11 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
 func TestArrayStack_Push(t *testing.T) {
@@ -75,3 +77,111 @@ func TestArrayStack_Clear(t *testing.T) {
 	assert.Equal(true, stack.IsEmpty())
 	assert.Equal(0, stack.Size())
 }
+
+func TestArrayStack_PeakReturnsCopy(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_PeakReturnsCopy")
+
+	stack := NewArrayStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+
+	top, err := stack.Peak()
+	assert.IsNil(err)
+	*top = 100
+
+	assert.Equal([]int{2, 1}, stack.Data())
+}
+
+func TestArrayStack_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_Iterator")
+
+	stack := NewArrayStack[int]()
+	assert.Equal(false, stack.Iterator().HasNext())
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	iter := stack.Iterator()
+	stack.Push(4)
+	stack.Pop()
+	stack.Pop()
+
+	items := []int{}
+	for iter.HasNext() {
+		item, ok := iter.Next()
+		assert.Equal(true, ok)
+		items = append(items, item)
+	}
+	_, ok := iter.Next()
+
+	// the iterator walks a snapshot from top to bottom
+	assert.Equal([]int{3, 2, 1}, items)
+	assert.Equal(false, ok)
+	assert.Equal([]int{2, 1}, iterator.ToSlice(stack.Iterator()))
+}
+
+func TestArrayStack_String(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_String")
+
+	stack := NewArrayStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+
+	assert.Equal("[2 1]", stack.String())
+	assert.Equal(true, stack.Contains(1))
+	assert.Equal(false, stack.Contains(3))
+
+	top, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(2, top)
+}
+
+func TestArrayStack_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_JSON")
+
+	stack := NewArrayStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	data, err := json.Marshal(stack)
+	assert.IsNil(err)
+	assert.Equal("[3,2,1]", string(data))
+
+	other := NewArrayStack[int]()
+	other.Push(4)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal([]int{3, 2, 1}, other.Values())
+	assert.Equal(3, other.Size())
+}
+
+func TestArrayStack_Values(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_Values")
+
+	stack := NewArrayStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+
+	values := stack.Values()
+	values[0] = 100
+
+	// Values returns a copy, the stack is not modified
+	assert.Equal([]int{2, 1}, stack.Values())
+}
+
+func TestArrayStack_Poll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestArrayStack_Poll")
+
+	stack := NewArrayStack[int]()
+	_, ok := stack.Poll()
+	assert.Equal(false, ok)
+
+	stack.Push(1)
+	stack.Push(2)
+
+	topItem, ok := stack.Poll()
+	assert.Equal(true, ok)
+	assert.Equal(2, topItem)
+	assert.Equal([]int{1}, stack.Data())
+}
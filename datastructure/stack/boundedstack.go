@@ -0,0 +1,171 @@
+package datastructure
+
+import (
+	"encoding/json"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Stack[any] = (*BoundedStack[any])(nil)
+
+// OverflowPolicy decides what a BoundedStack does when pushing into a full stack.
+type OverflowPolicy int
+
+const (
+	// RejectOnOverflow keeps the stack unchanged and discards the new element.
+	RejectOnOverflow OverflowPolicy = iota
+	// DropBottomOnOverflow makes Push discard the bottom (oldest) element to make room for the new one.
+	DropBottomOnOverflow
+)
+
+// BoundedStack implements stack with a fixed capacity.
+// It is backed by a ring buffer, so dropping the bottom element on overflow is O(1).
+type BoundedStack[T any] struct {
+	data     []T
+	bottom   int
+	length   int
+	capacity int
+	policy   OverflowPolicy
+}
+
+// NewBoundedStack return a empty BoundedStack pointer with the given capacity and overflow policy
+func NewBoundedStack[T any](capacity int, policy OverflowPolicy) *BoundedStack[T] {
+	if capacity <= 0 {
+		panic("NewBoundedStack: capacity should be positive")
+	}
+
+	return &BoundedStack[T]{
+		data:     make([]T, capacity),
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// Data return stack data, from top to bottom
+func (s *BoundedStack[T]) Data() []T {
+	data := make([]T, s.length)
+	for i := 0; i < s.length; i++ {
+		data[i] = s.data[s.index(s.length-1-i)]
+	}
+
+	return data
+}
+
+// Values return stack data, from top to bottom
+func (s *BoundedStack[T]) Values() []T {
+	return s.Data()
+}
+
+// Size return length of stack data
+func (s *BoundedStack[T]) Size() int {
+	return s.length
+}
+
+// Capacity return the max number of elements the stack can hold
+func (s *BoundedStack[T]) Capacity() int {
+	return s.capacity
+}
+
+// IsEmpty checks if stack is empty or not
+func (s *BoundedStack[T]) IsEmpty() bool {
+	return s.length == 0
+}
+
+// IsFull checks if stack is full or not
+func (s *BoundedStack[T]) IsFull() bool {
+	return s.length == s.capacity
+}
+
+// Push element into stack. if the stack is full, DropBottomOnOverflow removes the bottom element to make room for value,
+// RejectOnOverflow discards value, use Offer to know whether value is accepted or not.
+func (s *BoundedStack[T]) Push(value T) {
+	s.Offer(value)
+}
+
+// Offer push element into stack, returns false if the stack is full and the policy is RejectOnOverflow.
+func (s *BoundedStack[T]) Offer(value T) bool {
+	if s.IsFull() {
+		if s.policy != DropBottomOnOverflow {
+			return false
+		}
+
+		var zeroValue T
+		s.data[s.bottom] = zeroValue
+		s.bottom = (s.bottom + 1) % s.capacity
+		s.length--
+	}
+
+	s.data[s.index(s.length)] = value
+	s.length++
+
+	return true
+}
+
+// Pop delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *BoundedStack[T]) Pop() (T, bool) {
+	var zeroValue T
+	if s.IsEmpty() {
+		return zeroValue, false
+	}
+
+	i := s.index(s.length - 1)
+	top := s.data[i]
+	s.data[i] = zeroValue
+	s.length--
+
+	return top, true
+}
+
+// Poll is the same as Pop, it implements datastructure.Stack.
+func (s *BoundedStack[T]) Poll() (T, bool) {
+	return s.Pop()
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *BoundedStack[T]) Peek() (T, bool) {
+	if s.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	return s.data[s.index(s.length-1)], true
+}
+
+// Clear the stack data
+func (s *BoundedStack[T]) Clear() {
+	s.data = make([]T, s.capacity)
+	s.bottom = 0
+	s.length = 0
+}
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *BoundedStack[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Data())
+}
+
+// index return the position in data of the element which is offset elements above the bottom
+func (s *BoundedStack[T]) index(offset int) int {
+	return (s.bottom + offset) % s.capacity
+}
+
+// Contains checks if the value is in stack or not
+func (s *BoundedStack[T]) Contains(value T) bool {
+	return internal.ContainsValue(s.Data(), value)
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *BoundedStack[T]) String() string {
+	return internal.FormatValues(s.Data())
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *BoundedStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *BoundedStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
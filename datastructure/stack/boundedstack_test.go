@@ -0,0 +1,53 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+func TestBoundedStack_Reject(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBoundedStack_Reject")
+
+	stack := NewBoundedStack[int](2, RejectOnOverflow)
+	assert.Equal(true, stack.Offer(1))
+	assert.Equal(true, stack.Offer(2))
+	assert.Equal(true, stack.IsFull())
+	assert.Equal(false, stack.Offer(3))
+	stack.Push(4)
+	assert.Equal([]int{2, 1}, stack.Data())
+
+	top, ok := stack.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(2, top)
+	assert.Equal(1, stack.Size())
+}
+
+func TestBoundedStack_DropBottom(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBoundedStack_DropBottom")
+
+	stack := NewBoundedStack[int](3, DropBottomOnOverflow)
+	for i := 1; i <= 5; i++ {
+		assert.Equal(true, stack.Offer(i))
+	}
+
+	assert.Equal(3, stack.Size())
+	assert.Equal([]int{5, 4, 3}, stack.Data())
+	assert.Equal([]int{5, 4, 3}, iterator.ToSlice(stack.Iterator()))
+
+	top, _ := stack.Pop()
+	assert.Equal(5, top)
+	stack.Push(6)
+	stack.Push(7)
+	assert.Equal([]int{7, 6, 4}, stack.Data())
+
+	peek, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(7, peek)
+
+	stack.Clear()
+	assert.Equal(true, stack.IsEmpty())
+	_, ok = stack.Peek()
+	assert.Equal(false, ok)
+}
@@ -0,0 +1,133 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Stack[any] = (*ConcurrentStack[any])(nil)
+
+// ConcurrentStack is a stack guarded by a mutex, it is safe for concurrent use by multiple goroutines.
+type ConcurrentStack[T any] struct {
+	data []T
+	mu   sync.RWMutex
+}
+
+// NewConcurrentStack return a empty ConcurrentStack pointer
+func NewConcurrentStack[T any]() *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{data: []T{}}
+}
+
+// Data return a copy of stack data, from top to bottom
+func (s *ConcurrentStack[T]) Data() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l := len(s.data)
+	data := make([]T, l)
+	for i, v := range s.data {
+		data[l-1-i] = v
+	}
+
+	return data
+}
+
+// Values return stack data, from top to bottom
+func (s *ConcurrentStack[T]) Values() []T {
+	return s.Data()
+}
+
+// Size return length of stack data
+func (s *ConcurrentStack[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.data)
+}
+
+// IsEmpty checks if stack is empty or not
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Push element into stack
+func (s *ConcurrentStack[T]) Push(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = append(s.data, value)
+}
+
+// Pop delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *ConcurrentStack[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zeroValue T
+	l := len(s.data)
+	if l == 0 {
+		return zeroValue, false
+	}
+
+	top := s.data[l-1]
+	s.data[l-1] = zeroValue
+	s.data = s.data[:l-1]
+
+	return top, true
+}
+
+// Poll is the same as Pop, it implements datastructure.Stack.
+func (s *ConcurrentStack[T]) Poll() (T, bool) {
+	return s.Pop()
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *ConcurrentStack[T]) Peek() (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l := len(s.data)
+	if l == 0 {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	return s.data[l-1], true
+}
+
+// Clear the stack data
+func (s *ConcurrentStack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = []T{}
+}
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *ConcurrentStack[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Data())
+}
+
+// Contains checks if the value is in stack or not
+func (s *ConcurrentStack[T]) Contains(value T) bool {
+	return internal.ContainsValue(s.Data(), value)
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *ConcurrentStack[T]) String() string {
+	return internal.FormatValues(s.Data())
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *ConcurrentStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *ConcurrentStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
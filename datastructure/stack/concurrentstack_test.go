@@ -0,0 +1,72 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+func TestConcurrentStack_PushPop(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentStack_PushPop")
+
+	stack := NewConcurrentStack[int]()
+	_, ok := stack.Pop()
+	assert.Equal(false, ok)
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+	assert.Equal([]int{3, 2, 1}, stack.Data())
+
+	top, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(3, top)
+
+	top, ok = stack.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(3, top)
+	assert.Equal([]int{2, 1}, stack.Data())
+
+	stack.Clear()
+	assert.Equal(true, stack.IsEmpty())
+}
+
+func TestConcurrentStack_Concurrent(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentStack_Concurrent")
+
+	stack := NewConcurrentStack[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			stack.Push(n)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(100, stack.Size())
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stack.Pop()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(0, stack.Size())
+}
+
+func TestConcurrentStack_Iterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentStack_Iterator")
+
+	stack := NewConcurrentStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	assert.Equal([]int{3, 2, 1}, iterator.ToSlice(stack.Iterator()))
+}
@@ -1,12 +1,18 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
+var _ datastructure.Stack[any] = (*LinkedStack[any])(nil)
+
 // LinkedStack implements stack with link list
 type LinkedStack[T any] struct {
 	top    *datastructure.StackNode[T]
@@ -30,6 +36,11 @@ func (s *LinkedStack[T]) Data() []T {
 	return res
 }
 
+// Values return stack data, from top to bottom
+func (s *LinkedStack[T]) Values() []T {
+	return s.Data()
+}
+
 // Size return length of stack data
 func (s *LinkedStack[T]) Size() int {
 	return s.length
@@ -67,12 +78,41 @@ func (s *LinkedStack[T]) Pop() (*T, error) {
 	return &top.Value, nil
 }
 
-// Peak return the top element of stack then return it
+// Poll delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *LinkedStack[T]) Poll() (T, bool) {
+	top, err := s.Pop()
+	if err != nil {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return *top, true
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *LinkedStack[T]) Peek() (T, bool) {
+	if s.IsEmpty() {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return s.top.Value, true
+}
+
+// Peak return a copy of the top element of stack
+//
+// Deprecated: use Peek instead.
 func (s *LinkedStack[T]) Peak() (*T, error) {
 	if s.IsEmpty() {
 		return nil, errors.New("stack is empty")
 	}
-	return &s.top.Value, nil
+
+	topItem := s.top.Value
+
+	return &topItem, nil
+}
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *LinkedStack[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Data())
 }
 
 // Clear clear the stack data
@@ -81,7 +121,34 @@ func (s *LinkedStack[T]) Clear() {
 	s.length = 0
 }
 
+// Contains checks if the value is in stack or not
+func (s *LinkedStack[T]) Contains(value T) bool {
+	for current := s.top; current != nil; current = current.Next {
+		if reflect.DeepEqual(current.Value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *LinkedStack[T]) String() string {
+	return internal.FormatValues(s.Data())
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *LinkedStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *LinkedStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
+
 // Print all nodes info of stack link
+//
+// Deprecated: use String instead.
 func (s *LinkedStack[T]) Print() {
 	current := s.top
 	info := "[ "
@@ -1,6 +1,7 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
@@ -76,3 +77,54 @@ func TestLinkedStack_Empty(t *testing.T) {
 	assert.Equal(true, stack.IsEmpty())
 	assert.Equal(0, stack.Size())
 }
+
+func TestLinkedStack_String(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedStack_String")
+
+	stack := NewLinkedStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+
+	assert.Equal("[2 1]", stack.String())
+	assert.Equal(true, stack.Contains(1))
+	assert.Equal(false, stack.Contains(3))
+
+	top, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(2, top)
+}
+
+func TestLinkedStack_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedStack_JSON")
+
+	stack := NewLinkedStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	data, err := json.Marshal(stack)
+	assert.IsNil(err)
+	assert.Equal("[3,2,1]", string(data))
+
+	other := NewLinkedStack[int]()
+	other.Push(4)
+	assert.IsNil(json.Unmarshal(data, other))
+	assert.Equal([]int{3, 2, 1}, other.Values())
+	assert.Equal(3, other.Size())
+}
+
+func TestLinkedStack_Poll(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLinkedStack_Poll")
+
+	stack := NewLinkedStack[int]()
+	_, ok := stack.Poll()
+	assert.Equal(false, ok)
+
+	stack.Push(1)
+	stack.Push(2)
+
+	topItem, ok := stack.Poll()
+	assert.Equal(true, ok)
+	assert.Equal(2, topItem)
+	assert.Equal([]int{1}, stack.Data())
+}
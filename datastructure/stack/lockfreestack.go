@@ -0,0 +1,142 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Stack[any] = (*LockFreeStack[any])(nil)
+
+// LockFreeStack is a Treiber stack, a lock-free stack which uses compare-and-swap on its top node.
+// it is safe for concurrent use by multiple goroutines.
+type LockFreeStack[T any] struct {
+	top    unsafe.Pointer // *datastructure.StackNode[T]
+	length int64
+}
+
+// NewLockFreeStack return a empty LockFreeStack pointer
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push element into stack
+func (s *LockFreeStack[T]) Push(value T) {
+	newNode := datastructure.NewStackNode(value)
+
+	for {
+		top := atomic.LoadPointer(&s.top)
+		newNode.Next = (*datastructure.StackNode[T])(top)
+
+		if atomic.CompareAndSwapPointer(&s.top, top, unsafe.Pointer(newNode)) {
+			atomic.AddInt64(&s.length, 1)
+			return
+		}
+	}
+}
+
+// Pop delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *LockFreeStack[T]) Pop() (T, bool) {
+	for {
+		top := atomic.LoadPointer(&s.top)
+		if top == nil {
+			var zeroValue T
+			return zeroValue, false
+		}
+
+		// nodes are never reused, so the ABA problem can not happen here.
+		node := (*datastructure.StackNode[T])(top)
+		if atomic.CompareAndSwapPointer(&s.top, top, unsafe.Pointer(node.Next)) {
+			atomic.AddInt64(&s.length, -1)
+			return node.Value, true
+		}
+	}
+}
+
+// Poll is the same as Pop, it implements datastructure.Stack.
+func (s *LockFreeStack[T]) Poll() (T, bool) {
+	return s.Pop()
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *LockFreeStack[T]) Peek() (T, bool) {
+	top := (*datastructure.StackNode[T])(atomic.LoadPointer(&s.top))
+	if top == nil {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	return top.Value, true
+}
+
+// Values return stack data, from top to bottom
+func (s *LockFreeStack[T]) Values() []T {
+	return s.Data()
+}
+
+// Size return length of stack data
+func (s *LockFreeStack[T]) Size() int {
+	return int(atomic.LoadInt64(&s.length))
+}
+
+// IsEmpty checks if stack is empty or not
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return atomic.LoadPointer(&s.top) == nil
+}
+
+// Data return a snapshot of stack data, from top to bottom
+func (s *LockFreeStack[T]) Data() []T {
+	data := []T{}
+
+	current := (*datastructure.StackNode[T])(atomic.LoadPointer(&s.top))
+	for current != nil {
+		data = append(data, current.Value)
+		current = current.Next
+	}
+
+	return data
+}
+
+// Clear the stack data
+func (s *LockFreeStack[T]) Clear() {
+	for {
+		top := atomic.LoadPointer(&s.top)
+		if atomic.CompareAndSwapPointer(&s.top, top, nil) {
+			var count int64
+			for node := (*datastructure.StackNode[T])(top); node != nil; node = node.Next {
+				count++
+			}
+			atomic.AddInt64(&s.length, -count)
+			return
+		}
+	}
+}
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *LockFreeStack[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Data())
+}
+
+// Contains checks if the value is in stack or not
+func (s *LockFreeStack[T]) Contains(value T) bool {
+	return internal.ContainsValue(s.Data(), value)
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *LockFreeStack[T]) String() string {
+	return internal.FormatValues(s.Data())
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *LockFreeStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *LockFreeStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
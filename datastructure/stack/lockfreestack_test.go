@@ -0,0 +1,71 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+func TestLockFreeStack_PushPop(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLockFreeStack_PushPop")
+
+	stack := NewLockFreeStack[int]()
+	_, ok := stack.Pop()
+	assert.Equal(false, ok)
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+	assert.Equal([]int{3, 2, 1}, stack.Data())
+	assert.Equal(3, stack.Size())
+
+	top, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(3, top)
+
+	top, ok = stack.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(3, top)
+	assert.Equal([]int{2, 1}, iterator.ToSlice(stack.Iterator()))
+
+	stack.Clear()
+	assert.Equal(true, stack.IsEmpty())
+	assert.Equal(0, stack.Size())
+}
+
+func TestLockFreeStack_Concurrent(t *testing.T) {
+	assert := internal.NewAssert(t, "TestLockFreeStack_Concurrent")
+
+	stack := NewLockFreeStack[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			stack.Push(n)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(100, stack.Size())
+
+	var mu sync.Mutex
+	seen := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, ok := stack.Pop(); ok {
+				mu.Lock()
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(100, len(seen))
+	assert.Equal(true, stack.IsEmpty())
+}
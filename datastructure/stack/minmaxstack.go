@@ -0,0 +1,155 @@
+package datastructure
+
+import (
+	"encoding/json"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+	"github.com/serialt/lancet/lancetconstraints"
+)
+
+var _ datastructure.Stack[any] = (*MinMaxStack[any])(nil)
+
+// minMaxItem keeps the minimum and maximum values of the stack at the time the item was pushed.
+type minMaxItem[T any] struct {
+	value T
+	min   T
+	max   T
+}
+
+// MinMaxStack is a stack which returns its minimum and maximum values in O(1) time.
+// type T should implements Compare function in lancetconstraints.Comparator interface.
+type MinMaxStack[T any] struct {
+	items      []minMaxItem[T]
+	comparator lancetconstraints.Comparator
+}
+
+// NewMinMaxStack return a empty MinMaxStack pointer
+// param `comparator` is used to compare values in the stack
+func NewMinMaxStack[T any](comparator lancetconstraints.Comparator) *MinMaxStack[T] {
+	return &MinMaxStack[T]{
+		items:      []minMaxItem[T]{},
+		comparator: comparator,
+	}
+}
+
+// Data return stack data, from top to bottom
+func (s *MinMaxStack[T]) Data() []T {
+	l := len(s.items)
+	data := make([]T, l)
+	for i, item := range s.items {
+		data[l-1-i] = item.value
+	}
+
+	return data
+}
+
+// Values return stack data, from top to bottom
+func (s *MinMaxStack[T]) Values() []T {
+	return s.Data()
+}
+
+// Size return length of stack data
+func (s *MinMaxStack[T]) Size() int {
+	return len(s.items)
+}
+
+// IsEmpty checks if stack is empty or not
+func (s *MinMaxStack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Push element into stack
+func (s *MinMaxStack[T]) Push(value T) {
+	item := minMaxItem[T]{value: value, min: value, max: value}
+
+	if l := len(s.items); l > 0 {
+		top := s.items[l-1]
+		if s.comparator.Compare(top.min, value) < 0 {
+			item.min = top.min
+		}
+		if s.comparator.Compare(top.max, value) > 0 {
+			item.max = top.max
+		}
+	}
+
+	s.items = append(s.items, item)
+}
+
+// Pop delete the top element of stack then return it, if stack is empty, return zero value and false
+func (s *MinMaxStack[T]) Pop() (T, bool) {
+	l := len(s.items)
+	if l == 0 {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	top := s.items[l-1]
+	s.items[l-1] = minMaxItem[T]{}
+	s.items = s.items[:l-1]
+
+	return top.value, true
+}
+
+// Poll is the same as Pop, it implements datastructure.Stack.
+func (s *MinMaxStack[T]) Poll() (T, bool) {
+	return s.Pop()
+}
+
+// Peek return the top element of stack, if stack is empty, return zero value and false
+func (s *MinMaxStack[T]) Peek() (T, bool) {
+	top, ok := s.top()
+	return top.value, ok
+}
+
+// Min return the minimum element of stack, if stack is empty, return zero value and false
+func (s *MinMaxStack[T]) Min() (T, bool) {
+	top, ok := s.top()
+	return top.min, ok
+}
+
+// Max return the maximum element of stack, if stack is empty, return zero value and false
+func (s *MinMaxStack[T]) Max() (T, bool) {
+	top, ok := s.top()
+	return top.max, ok
+}
+
+// Clear the stack data
+func (s *MinMaxStack[T]) Clear() {
+	s.items = []minMaxItem[T]{}
+}
+
+// Iterator returns an iterator over a snapshot of the stack, from top to bottom.
+func (s *MinMaxStack[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(s.Data())
+}
+
+func (s *MinMaxStack[T]) top() (minMaxItem[T], bool) {
+	l := len(s.items)
+	if l == 0 {
+		return minMaxItem[T]{}, false
+	}
+
+	return s.items[l-1], true
+}
+
+// Contains checks if the value is in stack or not
+func (s *MinMaxStack[T]) Contains(value T) bool {
+	return internal.ContainsValue(s.Data(), value)
+}
+
+// String returns the stack data from top to bottom, eg. [3 2 1]
+func (s *MinMaxStack[T]) String() string {
+	return internal.FormatValues(s.Data())
+}
+
+// MarshalJSON encodes the stack as a json array, from top to bottom
+func (s *MinMaxStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
+}
+
+// UnmarshalJSON decodes a json array listed from top to bottom, and replaces the stack elements with them
+func (s *MinMaxStack[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalStack(data, s.Clear, s.Push)
+}
@@ -0,0 +1,55 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+type intComparator struct{}
+
+func (c *intComparator) Compare(v1, v2 any) int {
+	val1, _ := v1.(int)
+	val2, _ := v2.(int)
+
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return 1
+	}
+	return 0
+}
+
+func TestMinMaxStack(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMinMaxStack")
+
+	stack := NewMinMaxStack[int](&intComparator{})
+	_, ok := stack.Min()
+	assert.Equal(false, ok)
+
+	for _, v := range []int{3, 5, 1, 4, 7} {
+		stack.Push(v)
+	}
+	assert.Equal([]int{7, 4, 1, 5, 3}, stack.Data())
+
+	min, _ := stack.Min()
+	max, _ := stack.Max()
+	assert.Equal(1, min)
+	assert.Equal(7, max)
+
+	stack.Pop()
+	stack.Pop()
+	stack.Pop()
+
+	min, _ = stack.Min()
+	max, _ = stack.Max()
+	assert.Equal(3, min)
+	assert.Equal(5, max)
+
+	top, ok := stack.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(5, top)
+
+	stack.Clear()
+	assert.Equal(true, stack.IsEmpty())
+}
@@ -0,0 +1,21 @@
+package datastructure
+
+import (
+	"encoding/json"
+)
+
+// unmarshalStack decodes a json array listed from top to bottom, then clears the stack and pushes the elements from bottom to top.
+func unmarshalStack[T any](data []byte, clear func(), push func(value T)) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	clear()
+
+	for i := len(values) - 1; i >= 0; i-- {
+		push(values[i])
+	}
+
+	return nil
+}
@@ -1,12 +1,18 @@
 package datastructure
 
 import (
+	"encoding/json"
+	"errors"
 	"math"
 
 	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 	"github.com/serialt/lancet/lancetconstraints"
 )
 
+var _ datastructure.Collection[any] = (*BSTree[any])(nil)
+
 // BSTree is a binary search tree data structure in which each node has at most two children,
 // which are referred to as the left child and the right child.
 // In BSTree: leftNode < rootNode < rightNode
@@ -36,7 +42,7 @@ func (t *BSTree[T]) Insert(data T) {
 
 // DeletetNode delete data into BSTree
 func (t *BSTree[T]) Delete(data T) {
-	deleteTreeNode(t.root, data, t.comparator)
+	t.root = deleteTreeNode(t.root, data, t.comparator)
 }
 
 // NodeLevel get node level in BSTree
@@ -100,7 +106,80 @@ func hasSubTree[T any](superTreeRoot, subTreeRoot *datastructure.TreeNode[T],
 	return result
 }
 
+// Size returns the number of nodes in BSTree
+func (t *BSTree[T]) Size() int {
+	return len(inOrderTraverse(t.root))
+}
+
+// IsEmpty checks if BSTree has no node or not
+func (t *BSTree[T]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear removes all nodes of BSTree
+func (t *BSTree[T]) Clear() {
+	t.root = nil
+}
+
+// Values returns node values of BSTree in order
+func (t *BSTree[T]) Values() []T {
+	return inOrderTraverse(t.root)
+}
+
+// Contains checks if value is in BSTree or not
+func (t *BSTree[T]) Contains(value T) bool {
+	node := t.root
+	for node != nil {
+		switch c := t.comparator.Compare(value, node.Value); {
+		case c < 0:
+			node = node.Left
+		case c > 0:
+			node = node.Right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Iterator returns an iterator over node values of BSTree in order
+func (t *BSTree[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(inOrderTraverse(t.root))
+}
+
+// String returns node values of BSTree in order, eg. [1 2 3]
+func (t *BSTree[T]) String() string {
+	return internal.FormatValues(inOrderTraverse(t.root))
+}
+
+// MarshalJSON encodes node values of BSTree in order as a json array
+func (t *BSTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(inOrderTraverse(t.root))
+}
+
+// UnmarshalJSON decodes a json array and replaces the node values of BSTree with them,
+// the tree should be created by NewBSTree first, so it has a comparator.
+func (t *BSTree[T]) UnmarshalJSON(data []byte) error {
+	if t.comparator == nil {
+		return errors.New("BSTree: comparator is nil")
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	t.Clear()
+	for _, v := range values {
+		t.Insert(v)
+	}
+
+	return nil
+}
+
 // Print the bstree structure
+//
+// Deprecated: use String instead.
 func (t *BSTree[T]) Print() {
 	maxLevel := t.NodeLevel(t.root)
 	nodes := []*datastructure.TreeNode[T]{t.root}
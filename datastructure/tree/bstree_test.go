@@ -1,6 +1,7 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/serialt/lancet/internal"
@@ -113,6 +114,28 @@ func TestBSTree_Delete(t *testing.T) {
 	// assert.Equal([]int{2, 5, 7}, acturl2)
 }
 
+func TestBSTree_DeleteRoot(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBSTree_DeleteRoot")
+
+	bstree := NewBSTree(6, &intComparator{})
+	bstree.Insert(7)
+	bstree.Insert(5)
+	bstree.Insert(2)
+
+	bstree.Delete(6)
+	assert.Equal([]int{2, 5, 7}, bstree.InOrderTraverse())
+	assert.Equal(3, bstree.Size())
+	assert.Equal(false, bstree.Contains(6))
+
+	bstree.Delete(7)
+	bstree.Delete(5)
+	bstree.Delete(2)
+	assert.Equal(true, bstree.IsEmpty())
+
+	bstree.Insert(1)
+	assert.Equal([]int{1}, bstree.InOrderTraverse())
+}
+
 func TestBSTree_Depth(t *testing.T) {
 	assert := internal.NewAssert(t, "TestBSTree_Depth")
 
@@ -145,3 +168,44 @@ func TestBSTree_IsSubTree(t *testing.T) {
 	assert.Equal(true, superTree.HasSubTree(subTree))
 	assert.Equal(false, subTree.HasSubTree(superTree))
 }
+
+func TestBSTree_Collection(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBSTree_Collection")
+
+	bstree := NewBSTree(6, &intComparator{})
+	bstree.Insert(7)
+	bstree.Insert(5)
+	bstree.Insert(2)
+
+	assert.Equal(4, bstree.Size())
+	assert.Equal([]int{2, 5, 6, 7}, bstree.Values())
+	assert.Equal(true, bstree.Contains(5))
+	assert.Equal(false, bstree.Contains(3))
+	assert.Equal("[2 5 6 7]", bstree.String())
+
+	data, err := bstree.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[2,5,6,7]", string(data))
+
+	bstree.Clear()
+	assert.Equal(true, bstree.IsEmpty())
+}
+
+func TestBSTree_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBSTree_JSON")
+
+	bstree := NewBSTree(6, &intComparator{})
+	bstree.Insert(7)
+	bstree.Insert(5)
+
+	data, err := json.Marshal(bstree)
+	assert.IsNil(err)
+	assert.Equal("[5,6,7]", string(data))
+
+	decoded := NewBSTree(1, &intComparator{})
+	assert.IsNil(json.Unmarshal([]byte("[3,1,2]"), decoded))
+	assert.Equal([]int{1, 2, 3}, decoded.InOrderTraverse())
+	assert.Equal([]int{3, 1, 2}, decoded.PreOrderTraverse())
+
+	assert.IsNotNil(json.Unmarshal(data, &BSTree[int]{}))
+}
@@ -0,0 +1,172 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+	"golang.org/x/exp/constraints"
+)
+
+var _ datastructure.Collection[int] = (*FenwickTree[int])(nil)
+
+// FenwickTree (binary indexed tree) supports range sum queries, point updates and range add updates in O(log n) time.
+// it keeps two internal trees so that a range add and a range sum can be answered with prefix sums.
+type FenwickTree[T constraints.Integer | constraints.Float] struct {
+	b1   []T
+	b2   []T
+	size int
+}
+
+// NewFenwickTree create a FenwickTree pointer holding the given data
+func NewFenwickTree[T constraints.Integer | constraints.Float](data []T) *FenwickTree[T] {
+	ft := &FenwickTree[T]{
+		b1:   make([]T, len(data)+1),
+		b2:   make([]T, len(data)+1),
+		size: len(data),
+	}
+
+	for i, v := range data {
+		ft.rangeAdd(i, i, v)
+	}
+
+	return ft
+}
+
+// Size return the number of elements in the tree
+func (ft *FenwickTree[T]) Size() int {
+	return ft.size
+}
+
+// Add adds delta to the element at index
+func (ft *FenwickTree[T]) Add(index int, delta T) error {
+	return ft.RangeAdd(index, index, delta)
+}
+
+// Set replaces the element at index with value
+func (ft *FenwickTree[T]) Set(index int, value T) error {
+	current, err := ft.Get(index)
+	if err != nil {
+		return err
+	}
+
+	ft.rangeAdd(index, index, value-current)
+
+	return nil
+}
+
+// Get returns the element at index
+func (ft *FenwickTree[T]) Get(index int) (T, error) {
+	return ft.RangeSum(index, index)
+}
+
+// RangeAdd adds delta to every element in the range [left, right]
+func (ft *FenwickTree[T]) RangeAdd(left, right int, delta T) error {
+	if err := ft.checkRange(left, right); err != nil {
+		return err
+	}
+
+	ft.rangeAdd(left, right, delta)
+
+	return nil
+}
+
+// PrefixSum returns the sum of elements in the range [0, index]
+func (ft *FenwickTree[T]) PrefixSum(index int) (T, error) {
+	return ft.RangeSum(0, index)
+}
+
+// RangeSum returns the sum of elements in the range [left, right]
+func (ft *FenwickTree[T]) RangeSum(left, right int) (T, error) {
+	if err := ft.checkRange(left, right); err != nil {
+		var zeroValue T
+		return zeroValue, err
+	}
+
+	return ft.prefixSum(right+1) - ft.prefixSum(left), nil
+}
+
+// Values returns all elements in the tree
+func (ft *FenwickTree[T]) Values() []T {
+	values := make([]T, ft.size)
+	for i := range values {
+		values[i] = ft.prefixSum(i+1) - ft.prefixSum(i)
+	}
+
+	return values
+}
+
+// IsEmpty checks if the tree has no element or not
+func (ft *FenwickTree[T]) IsEmpty() bool {
+	return ft.size == 0
+}
+
+// Contains checks if the value is in the tree or not
+func (ft *FenwickTree[T]) Contains(value T) bool {
+	return internal.ContainsValue(ft.Values(), value)
+}
+
+// Iterator returns an iterator over a snapshot of all elements in the tree
+func (ft *FenwickTree[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(ft.Values())
+}
+
+// String returns all elements in the tree, eg. [1 2 3]
+func (ft *FenwickTree[T]) String() string {
+	return internal.FormatValues(ft.Values())
+}
+
+// MarshalJSON encodes all elements in the tree as a json array
+func (ft *FenwickTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ft.Values())
+}
+
+// UnmarshalJSON decodes a json array and replaces the elements in the tree with them
+func (ft *FenwickTree[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*ft = *NewFenwickTree(values)
+
+	return nil
+}
+
+func (ft *FenwickTree[T]) checkRange(left, right int) error {
+	if left < 0 || right >= ft.size || left > right {
+		return errors.New("index out of range")
+	}
+	return nil
+}
+
+// rangeAdd adds delta to the zero-based range [left, right]
+func (ft *FenwickTree[T]) rangeAdd(left, right int, delta T) {
+	l, r := left+1, right+1
+
+	ft.add(ft.b1, l, delta)
+	ft.add(ft.b1, r+1, -delta)
+	ft.add(ft.b2, l, delta*T(l-1))
+	ft.add(ft.b2, r+1, -delta*T(r))
+}
+
+// prefixSum returns the sum of the first n elements
+func (ft *FenwickTree[T]) prefixSum(n int) T {
+	return ft.sum(ft.b1, n)*T(n) - ft.sum(ft.b2, n)
+}
+
+func (ft *FenwickTree[T]) add(tree []T, i int, delta T) {
+	for ; i <= ft.size; i += i & -i {
+		tree[i] += delta
+	}
+}
+
+func (ft *FenwickTree[T]) sum(tree []T, i int) T {
+	var result T
+	for ; i > 0; i -= i & -i {
+		result += tree[i]
+	}
+	return result
+}
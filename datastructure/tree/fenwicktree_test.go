@@ -0,0 +1,56 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestFenwickTree(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFenwickTree")
+
+	ft := NewFenwickTree([]int{1, 2, 3, 4, 5})
+
+	sum, err := ft.PrefixSum(2)
+	assert.IsNil(err)
+	assert.Equal(6, sum)
+
+	sum, _ = ft.RangeSum(1, 3)
+	assert.Equal(9, sum)
+
+	ft.Add(2, 10)
+	sum, _ = ft.RangeSum(1, 3)
+	assert.Equal(19, sum)
+
+	ft.RangeAdd(0, 4, 1)
+	sum, _ = ft.RangeSum(0, 4)
+	assert.Equal(30, sum)
+
+	ft.Set(0, 0)
+	v, _ := ft.Get(0)
+	assert.Equal(0, v)
+	v, _ = ft.Get(2)
+	assert.Equal(14, v)
+
+	_, err = ft.RangeSum(2, 1)
+	assert.IsNotNil(err)
+	assert.IsNotNil(ft.Add(5, 1))
+}
+
+func TestFenwickTree_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFenwickTree_JSON")
+
+	ft := NewFenwickTree([]int{1, 2, 3})
+	data, err := json.Marshal(ft)
+	assert.IsNil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	decoded := NewFenwickTree([]int{9})
+	assert.IsNil(json.Unmarshal([]byte("[4,5]"), decoded))
+	assert.Equal([]int{4, 5}, decoded.Values())
+
+	sum, err := decoded.RangeSum(0, 1)
+	assert.IsNil(err)
+	assert.Equal(9, sum)
+}
@@ -0,0 +1,347 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+	"github.com/serialt/lancet/lancetconstraints"
+)
+
+var _ datastructure.Collection[Interval[any, any]] = (*IntervalTree[any, any])(nil)
+
+// Interval is a closed interval [Low, High] with an attached Value.
+type Interval[T any, V any] struct {
+	Low   T `json:"low"`
+	High  T `json:"high"`
+	Value V `json:"value"`
+}
+
+// IntervalTree is a self-balancing (AVL) binary search tree of intervals ordered by their low endpoint,
+// every node is augmented with the max high endpoint of its subtree, so overlap queries take O(log n + k) time.
+// type T should implements Compare function in lancetconstraints.Comparator interface.
+type IntervalTree[T any, V any] struct {
+	root       *intervalNode[T, V]
+	size       int
+	comparator lancetconstraints.Comparator
+}
+
+type intervalNode[T any, V any] struct {
+	interval Interval[T, V]
+	max      T
+	height   int
+	left     *intervalNode[T, V]
+	right    *intervalNode[T, V]
+}
+
+// NewIntervalTree create a empty IntervalTree pointer
+// param `comparator` is used to compare the endpoints of intervals
+func NewIntervalTree[T any, V any](comparator lancetconstraints.Comparator) *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{comparator: comparator}
+}
+
+// Size return the number of intervals in the tree
+func (t *IntervalTree[T, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty or not
+func (t *IntervalTree[T, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Insert interval [low, high] with value into the tree. if low is greater than high, they are swapped.
+func (t *IntervalTree[T, V]) Insert(low, high T, value V) {
+	if t.comparator.Compare(low, high) > 0 {
+		low, high = high, low
+	}
+
+	t.root = t.insert(t.root, Interval[T, V]{Low: low, High: high, Value: value})
+	t.size++
+}
+
+// Delete remove the interval which has the same endpoints and value from the tree,
+// returns false if there is no such interval.
+func (t *IntervalTree[T, V]) Delete(low, high T, value V) bool {
+	if t.comparator.Compare(low, high) > 0 {
+		low, high = high, low
+	}
+
+	var deleted bool
+	t.root = t.delete(t.root, Interval[T, V]{Low: low, High: high, Value: value}, &deleted)
+	if deleted {
+		t.size--
+	}
+
+	return deleted
+}
+
+// Overlap returns all intervals which overlap with [low, high], ordered by their low endpoint.
+func (t *IntervalTree[T, V]) Overlap(low, high T) []Interval[T, V] {
+	if t.comparator.Compare(low, high) > 0 {
+		low, high = high, low
+	}
+
+	result := []Interval[T, V]{}
+	t.overlap(t.root, low, high, &result)
+
+	return result
+}
+
+// Stab returns all intervals which contain point, ordered by their low endpoint.
+func (t *IntervalTree[T, V]) Stab(point T) []Interval[T, V] {
+	return t.Overlap(point, point)
+}
+
+// AnyOverlap checks if there is any interval overlapping with [low, high] or not.
+func (t *IntervalTree[T, V]) AnyOverlap(low, high T) bool {
+	if t.comparator.Compare(low, high) > 0 {
+		low, high = high, low
+	}
+
+	node := t.root
+	for node != nil {
+		if t.overlaps(node.interval, low, high) {
+			return true
+		}
+		if node.left != nil && t.comparator.Compare(node.left.max, low) >= 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return false
+}
+
+// Intervals returns all intervals in the tree, ordered by their low endpoint.
+func (t *IntervalTree[T, V]) Intervals() []Interval[T, V] {
+	result := make([]Interval[T, V], 0, t.size)
+
+	var traverse func(node *intervalNode[T, V])
+	traverse = func(node *intervalNode[T, V]) {
+		if node == nil {
+			return
+		}
+		traverse(node.left)
+		result = append(result, node.interval)
+		traverse(node.right)
+	}
+	traverse(t.root)
+
+	return result
+}
+
+// Values returns all intervals in the tree, ordered by their low endpoint.
+func (t *IntervalTree[T, V]) Values() []Interval[T, V] {
+	return t.Intervals()
+}
+
+// Contains checks if the interval is in the tree or not
+func (t *IntervalTree[T, V]) Contains(interval Interval[T, V]) bool {
+	return internal.ContainsValue(t.Overlap(interval.Low, interval.High), interval)
+}
+
+// Iterator returns an iterator over all intervals in the tree, ordered by their low endpoint.
+func (t *IntervalTree[T, V]) Iterator() iterator.Iterator[Interval[T, V]] {
+	return iterator.FromSlice(t.Intervals())
+}
+
+// String returns all intervals in the tree, eg. [[1, 3]:a [2, 5]:b]
+func (t *IntervalTree[T, V]) String() string {
+	result := "["
+	for i, v := range t.Intervals() {
+		if i > 0 {
+			result += " "
+		}
+		result += fmt.Sprintf("[%v, %v]:%v", v.Low, v.High, v.Value)
+	}
+
+	return result + "]"
+}
+
+// MarshalJSON encodes all intervals in the tree as a json array, ordered by their low endpoint.
+func (t *IntervalTree[T, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Intervals())
+}
+
+// UnmarshalJSON decodes a json array of intervals and replaces the intervals in the tree with them,
+// the tree should be created by NewIntervalTree first, so it has a comparator.
+func (t *IntervalTree[T, V]) UnmarshalJSON(data []byte) error {
+	if t.comparator == nil {
+		return errors.New("IntervalTree: comparator is nil")
+	}
+
+	var intervals []Interval[T, V]
+	if err := json.Unmarshal(data, &intervals); err != nil {
+		return err
+	}
+
+	t.Clear()
+	for _, interval := range intervals {
+		t.Insert(interval.Low, interval.High, interval.Value)
+	}
+
+	return nil
+}
+
+// Clear remove all intervals in the tree
+func (t *IntervalTree[T, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *IntervalTree[T, V]) overlaps(interval Interval[T, V], low, high T) bool {
+	return t.comparator.Compare(interval.Low, high) <= 0 && t.comparator.Compare(low, interval.High) <= 0
+}
+
+func (t *IntervalTree[T, V]) overlap(node *intervalNode[T, V], low, high T, result *[]Interval[T, V]) {
+	// no interval in this subtree ends after low
+	if node == nil || t.comparator.Compare(node.max, low) < 0 {
+		return
+	}
+
+	t.overlap(node.left, low, high, result)
+
+	if t.overlaps(node.interval, low, high) {
+		*result = append(*result, node.interval)
+	}
+
+	// intervals in the right subtree start after node's low, skip them if node starts after high
+	if t.comparator.Compare(node.interval.Low, high) <= 0 {
+		t.overlap(node.right, low, high, result)
+	}
+}
+
+// compareInterval orders intervals by low endpoint, then by high endpoint.
+func (t *IntervalTree[T, V]) compareInterval(a, b Interval[T, V]) int {
+	if c := t.comparator.Compare(a.Low, b.Low); c != 0 {
+		return c
+	}
+	return t.comparator.Compare(a.High, b.High)
+}
+
+func (t *IntervalTree[T, V]) insert(node *intervalNode[T, V], interval Interval[T, V]) *intervalNode[T, V] {
+	if node == nil {
+		return &intervalNode[T, V]{interval: interval, max: interval.High, height: 1}
+	}
+
+	if t.compareInterval(interval, node.interval) < 0 {
+		node.left = t.insert(node.left, interval)
+	} else {
+		node.right = t.insert(node.right, interval)
+	}
+
+	return t.rebalance(node)
+}
+
+func (t *IntervalTree[T, V]) delete(node *intervalNode[T, V], interval Interval[T, V], deleted *bool) *intervalNode[T, V] {
+	if node == nil {
+		return nil
+	}
+
+	c := t.compareInterval(interval, node.interval)
+	switch {
+	case c < 0:
+		node.left = t.delete(node.left, interval, deleted)
+	case c > 0:
+		node.right = t.delete(node.right, interval, deleted)
+	case !reflect.DeepEqual(interval.Value, node.interval.Value):
+		// intervals with the same endpoints may be stored on either side after rotations
+		node.left = t.delete(node.left, interval, deleted)
+		if !*deleted {
+			node.right = t.delete(node.right, interval, deleted)
+		}
+	default:
+		*deleted = true
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.interval = successor.interval
+
+		var removed bool
+		node.right = t.delete(node.right, successor.interval, &removed)
+	}
+
+	return t.rebalance(node)
+}
+
+func (t *IntervalTree[T, V]) update(node *intervalNode[T, V]) {
+	node.height = 1 + maxInt(nodeHeight(node.left), nodeHeight(node.right))
+
+	node.max = node.interval.High
+	if node.left != nil && t.comparator.Compare(node.left.max, node.max) > 0 {
+		node.max = node.left.max
+	}
+	if node.right != nil && t.comparator.Compare(node.right.max, node.max) > 0 {
+		node.max = node.right.max
+	}
+}
+
+func (t *IntervalTree[T, V]) rebalance(node *intervalNode[T, V]) *intervalNode[T, V] {
+	t.update(node)
+
+	balance := nodeHeight(node.left) - nodeHeight(node.right)
+	if balance > 1 {
+		if nodeHeight(node.left.left) < nodeHeight(node.left.right) {
+			node.left = t.rotateLeft(node.left)
+		}
+		return t.rotateRight(node)
+	}
+	if balance < -1 {
+		if nodeHeight(node.right.right) < nodeHeight(node.right.left) {
+			node.right = t.rotateRight(node.right)
+		}
+		return t.rotateLeft(node)
+	}
+
+	return node
+}
+
+func (t *IntervalTree[T, V]) rotateLeft(node *intervalNode[T, V]) *intervalNode[T, V] {
+	right := node.right
+	node.right = right.left
+	right.left = node
+
+	t.update(node)
+	t.update(right)
+
+	return right
+}
+
+func (t *IntervalTree[T, V]) rotateRight(node *intervalNode[T, V]) *intervalNode[T, V] {
+	left := node.left
+	node.left = left.right
+	left.right = node
+
+	t.update(node)
+	t.update(left)
+
+	return left
+}
+
+func nodeHeight[T any, V any](node *intervalNode[T, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestIntervalTree_Overlap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIntervalTree_Overlap")
+
+	tree := NewIntervalTree[int, string](&intComparator{})
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+
+	assert.Equal(6, tree.Size())
+
+	values := func(intervals []Interval[int, string]) []string {
+		result := []string{}
+		for _, v := range intervals {
+			result = append(result, v.Value)
+		}
+		return result
+	}
+
+	assert.Equal([]string{"d", "b", "e", "a"}, values(tree.Overlap(14, 16)))
+	assert.Equal([]string{"b", "f"}, values(tree.Overlap(25, 30)))
+	assert.Equal([]string{}, values(tree.Overlap(41, 50)))
+	assert.Equal([]string{"d", "b", "a", "c"}, values(tree.Stab(18)))
+
+	assert.Equal(true, tree.AnyOverlap(0, 5))
+	assert.Equal(false, tree.AnyOverlap(0, 4))
+	assert.Equal(false, tree.AnyOverlap(41, 50))
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIntervalTree_Delete")
+
+	tree := NewIntervalTree[int, string](&intComparator{})
+	for i := 0; i < 20; i++ {
+		tree.Insert(i, i+2, "x")
+	}
+	tree.Insert(5, 7, "y")
+
+	assert.Equal(false, tree.Delete(5, 7, "z"))
+	assert.Equal(true, tree.Delete(5, 7, "y"))
+	assert.Equal(true, tree.Delete(5, 7, "x"))
+	assert.Equal(false, tree.Delete(5, 7, "x"))
+	assert.Equal(19, tree.Size())
+
+	result := tree.Stab(7)
+	assert.Equal(2, len(result))
+	assert.Equal(6, result[0].Low)
+	assert.Equal(7, result[1].Low)
+
+	for i := 0; i < 20; i++ {
+		tree.Delete(i, i+2, "x")
+	}
+	assert.Equal(true, tree.IsEmpty())
+	assert.Equal(0, len(tree.Intervals()))
+}
+
+func TestIntervalTree_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIntervalTree_JSON")
+
+	tree := NewIntervalTree[int, string](&intComparator{})
+	tree.Insert(5, 8, "b")
+	tree.Insert(1, 3, "a")
+
+	data, err := json.Marshal(tree)
+	assert.IsNil(err)
+	assert.Equal(`[{"low":1,"high":3,"value":"a"},{"low":5,"high":8,"value":"b"}]`, string(data))
+
+	decoded := NewIntervalTree[int, string](&intComparator{})
+	decoded.Insert(10, 20, "c")
+	assert.IsNil(json.Unmarshal(data, decoded))
+	assert.Equal(tree.Intervals(), decoded.Intervals())
+	assert.Equal(1, len(decoded.Stab(6)))
+
+	assert.IsNotNil(json.Unmarshal(data, &IntervalTree[int, string]{}))
+}
@@ -0,0 +1,359 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+	"github.com/serialt/lancet/lancetconstraints"
+	"golang.org/x/exp/constraints"
+)
+
+var _ datastructure.Collection[int] = (*SegmentTree[int])(nil)
+
+// SegmentTree is a segment tree, it supports range queries, point updates and range set updates in O(log n) time.
+// A sum segment tree also supports range add updates in O(log n) time. Range updates use lazy propagation.
+type SegmentTree[T any] struct {
+	tree []T
+	size int
+
+	merge func(a, b T) T
+	// fill returns the aggregate value of n elements which are all equal to value
+	fill func(value T, n int) T
+
+	// lazy propagation of range set
+	assign   []T
+	assigned []bool
+
+	// lazy propagation of range add, only set for a sum segment tree
+	lazy    []T
+	pending []bool
+	plus    func(a, b T) T
+}
+
+// NewSumSegmentTree create a SegmentTree pointer whose Query returns the sum of range.
+func NewSumSegmentTree[T constraints.Integer | constraints.Float](data []T) *SegmentTree[T] {
+	plus := func(a, b T) T { return a + b }
+
+	st := &SegmentTree[T]{
+		merge: plus,
+		fill:  func(value T, n int) T { return value * T(n) },
+		plus:  plus,
+	}
+	st.reset(data)
+
+	return st
+}
+
+// NewMinSegmentTree create a SegmentTree pointer whose Query returns the minimum of range.
+// type T should implements Compare function in lancetconstraints.Comparator interface.
+func NewMinSegmentTree[T any](data []T, comparator lancetconstraints.Comparator) *SegmentTree[T] {
+	return newComparatorSegmentTree(data, func(a, b T) T {
+		if comparator.Compare(b, a) < 0 {
+			return b
+		}
+		return a
+	})
+}
+
+// NewMaxSegmentTree create a SegmentTree pointer whose Query returns the maximum of range.
+// type T should implements Compare function in lancetconstraints.Comparator interface.
+func NewMaxSegmentTree[T any](data []T, comparator lancetconstraints.Comparator) *SegmentTree[T] {
+	return newComparatorSegmentTree(data, func(a, b T) T {
+		if comparator.Compare(b, a) > 0 {
+			return b
+		}
+		return a
+	})
+}
+
+func newComparatorSegmentTree[T any](data []T, merge func(a, b T) T) *SegmentTree[T] {
+	st := &SegmentTree[T]{
+		merge: merge,
+		fill:  func(value T, n int) T { return value },
+	}
+	st.reset(data)
+
+	return st
+}
+
+// reset rebuilds the tree from data, and drops all pending range updates
+func (st *SegmentTree[T]) reset(data []T) {
+	st.tree = make([]T, 4*len(data))
+	st.size = len(data)
+	st.assign = make([]T, len(st.tree))
+	st.assigned = make([]bool, len(st.tree))
+	if st.plus != nil {
+		st.lazy = make([]T, len(st.tree))
+		st.pending = make([]bool, len(st.tree))
+	}
+
+	if st.size > 0 {
+		st.build(data, 1, 0, st.size-1)
+	}
+}
+
+// Size return the number of elements in the tree
+func (st *SegmentTree[T]) Size() int {
+	return st.size
+}
+
+// Query returns the aggregate value of elements in the range [left, right]
+func (st *SegmentTree[T]) Query(left, right int) (T, error) {
+	if err := st.checkRange(left, right); err != nil {
+		var zeroValue T
+		return zeroValue, err
+	}
+
+	return st.query(1, 0, st.size-1, left, right), nil
+}
+
+// Get returns the element at index
+func (st *SegmentTree[T]) Get(index int) (T, error) {
+	if err := st.checkRange(index, index); err != nil {
+		var zeroValue T
+		return zeroValue, err
+	}
+
+	return st.query(1, 0, st.size-1, index, index), nil
+}
+
+// Set replaces the element at index with value
+func (st *SegmentTree[T]) Set(index int, value T) error {
+	if err := st.checkRange(index, index); err != nil {
+		return err
+	}
+
+	st.set(1, 0, st.size-1, index, value)
+
+	return nil
+}
+
+// Add adds delta to the element at index, it is only supported by a sum segment tree.
+func (st *SegmentTree[T]) Add(index int, delta T) error {
+	return st.RangeAdd(index, index, delta)
+}
+
+// RangeAdd adds delta to every element in the range [left, right], it is only supported by a sum segment tree.
+func (st *SegmentTree[T]) RangeAdd(left, right int, delta T) error {
+	if st.plus == nil {
+		return errors.New("range add is only supported by sum segment tree")
+	}
+	if err := st.checkRange(left, right); err != nil {
+		return err
+	}
+
+	st.rangeAdd(1, 0, st.size-1, left, right, delta)
+
+	return nil
+}
+
+// RangeSet replaces every element in the range [left, right] with value
+func (st *SegmentTree[T]) RangeSet(left, right int, value T) error {
+	if err := st.checkRange(left, right); err != nil {
+		return err
+	}
+
+	st.rangeSet(1, 0, st.size-1, left, right, value)
+
+	return nil
+}
+
+// Data returns all elements in the tree
+func (st *SegmentTree[T]) Data() []T {
+	data := make([]T, st.size)
+	for i := range data {
+		data[i] = st.query(1, 0, st.size-1, i, i)
+	}
+
+	return data
+}
+
+// Values returns all elements in the tree
+func (st *SegmentTree[T]) Values() []T {
+	return st.Data()
+}
+
+// IsEmpty checks if the tree has no element or not
+func (st *SegmentTree[T]) IsEmpty() bool {
+	return st.size == 0
+}
+
+// Contains checks if the value is in the tree or not
+func (st *SegmentTree[T]) Contains(value T) bool {
+	return internal.ContainsValue(st.Data(), value)
+}
+
+// Iterator returns an iterator over a snapshot of all elements in the tree
+func (st *SegmentTree[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(st.Data())
+}
+
+// String returns all elements in the tree, eg. [1 2 3]
+func (st *SegmentTree[T]) String() string {
+	return internal.FormatValues(st.Data())
+}
+
+// MarshalJSON encodes all elements in the tree as a json array
+func (st *SegmentTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(st.Data())
+}
+
+// UnmarshalJSON decodes a json array and replaces the elements in the tree with them,
+// the tree should be created by a constructor first, so it knows how to aggregate a range.
+func (st *SegmentTree[T]) UnmarshalJSON(data []byte) error {
+	if st.merge == nil {
+		return errors.New("SegmentTree: merge function is nil")
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	st.reset(values)
+
+	return nil
+}
+
+func (st *SegmentTree[T]) checkRange(left, right int) error {
+	if left < 0 || right >= st.size || left > right {
+		return errors.New("index out of range")
+	}
+	return nil
+}
+
+// applySet replaces every element under node, which covers length elements, with value.
+// it drops the pending add of node, which is overwritten by value.
+func (st *SegmentTree[T]) applySet(node, length int, value T) {
+	st.tree[node] = st.fill(value, length)
+	st.assign[node] = value
+	st.assigned[node] = true
+
+	if st.pending != nil {
+		var zeroValue T
+		st.lazy[node] = zeroValue
+		st.pending[node] = false
+	}
+}
+
+// applyAdd adds delta to every element under node, which covers length elements.
+// if node has a pending set, delta is added to the value to set instead of being kept as a pending add.
+func (st *SegmentTree[T]) applyAdd(node, length int, delta T) {
+	st.tree[node] = st.plus(st.tree[node], st.fill(delta, length))
+
+	switch {
+	case st.assigned[node]:
+		st.assign[node] = st.plus(st.assign[node], delta)
+	case st.pending[node]:
+		st.lazy[node] = st.plus(st.lazy[node], delta)
+	default:
+		st.lazy[node] = delta
+		st.pending[node] = true
+	}
+}
+
+// pushDown passes the pending updates of node to its children, a node never has both a pending set and a pending add.
+func (st *SegmentTree[T]) pushDown(node, start, end int) {
+	mid := (start + end) / 2
+	var zeroValue T
+
+	if st.assigned[node] {
+		st.applySet(2*node, mid-start+1, st.assign[node])
+		st.applySet(2*node+1, end-mid, st.assign[node])
+		st.assign[node] = zeroValue
+		st.assigned[node] = false
+	}
+
+	if st.pending != nil && st.pending[node] {
+		st.applyAdd(2*node, mid-start+1, st.lazy[node])
+		st.applyAdd(2*node+1, end-mid, st.lazy[node])
+		st.lazy[node] = zeroValue
+		st.pending[node] = false
+	}
+}
+
+func (st *SegmentTree[T]) build(data []T, node, start, end int) {
+	if start == end {
+		st.tree[node] = data[start]
+		return
+	}
+
+	mid := (start + end) / 2
+	st.build(data, 2*node, start, mid)
+	st.build(data, 2*node+1, mid+1, end)
+	st.tree[node] = st.merge(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *SegmentTree[T]) query(node, start, end, left, right int) T {
+	if left <= start && end <= right {
+		return st.tree[node]
+	}
+
+	st.pushDown(node, start, end)
+
+	mid := (start + end) / 2
+	if right <= mid {
+		return st.query(2*node, start, mid, left, right)
+	}
+	if left > mid {
+		return st.query(2*node+1, mid+1, end, left, right)
+	}
+
+	return st.merge(st.query(2*node, start, mid, left, right), st.query(2*node+1, mid+1, end, left, right))
+}
+
+func (st *SegmentTree[T]) set(node, start, end, index int, value T) {
+	if start == end {
+		st.tree[node] = value
+		return
+	}
+
+	st.pushDown(node, start, end)
+
+	mid := (start + end) / 2
+	if index <= mid {
+		st.set(2*node, start, mid, index, value)
+	} else {
+		st.set(2*node+1, mid+1, end, index, value)
+	}
+	st.tree[node] = st.merge(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *SegmentTree[T]) rangeAdd(node, start, end, left, right int, delta T) {
+	if left <= start && end <= right {
+		st.applyAdd(node, end-start+1, delta)
+		return
+	}
+
+	st.pushDown(node, start, end)
+
+	mid := (start + end) / 2
+	if left <= mid {
+		st.rangeAdd(2*node, start, mid, left, right, delta)
+	}
+	if right > mid {
+		st.rangeAdd(2*node+1, mid+1, end, left, right, delta)
+	}
+	st.tree[node] = st.merge(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *SegmentTree[T]) rangeSet(node, start, end, left, right int, value T) {
+	if left <= start && end <= right {
+		st.applySet(node, end-start+1, value)
+		return
+	}
+
+	st.pushDown(node, start, end)
+
+	mid := (start + end) / 2
+	if left <= mid {
+		st.rangeSet(2*node, start, mid, left, right, value)
+	}
+	if right > mid {
+		st.rangeSet(2*node+1, mid+1, end, left, right, value)
+	}
+	st.tree[node] = st.merge(st.tree[2*node], st.tree[2*node+1])
+}
@@ -0,0 +1,243 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestSegmentTree_Sum(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_Sum")
+
+	st := NewSumSegmentTree([]int{1, 3, 5, 7, 9, 11})
+
+	sum, err := st.Query(1, 3)
+	assert.IsNil(err)
+	assert.Equal(15, sum)
+
+	assert.IsNil(st.Set(1, 10))
+	sum, _ = st.Query(1, 3)
+	assert.Equal(22, sum)
+
+	assert.IsNil(st.RangeAdd(0, 2, 1))
+	assert.Equal([]int{2, 11, 6, 7, 9, 11}, st.Data())
+
+	sum, _ = st.Query(0, 5)
+	assert.Equal(46, sum)
+
+	assert.IsNil(st.RangeSet(1, 4, 2))
+	assert.IsNil(st.RangeAdd(3, 5, 1))
+	assert.Equal([]int{2, 2, 2, 3, 3, 12}, st.Data())
+
+	sum, _ = st.Query(0, 5)
+	assert.Equal(24, sum)
+
+	_, err = st.Query(3, 6)
+	assert.IsNotNil(err)
+	assert.IsNotNil(st.Set(-1, 0))
+}
+
+type float64Comparator struct{}
+
+func (c *float64Comparator) Compare(v1, v2 any) int {
+	val1, _ := v1.(float64)
+	val2, _ := v2.(float64)
+
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return 1
+	}
+	return 0
+}
+
+func TestSegmentTree_MinMax(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_MinMax")
+
+	data := []float64{4, 2, 8, 6, 1}
+	minTree := NewMinSegmentTree(data, &float64Comparator{})
+	maxTree := NewMaxSegmentTree(data, &float64Comparator{})
+
+	min, _ := minTree.Query(0, 3)
+	max, _ := maxTree.Query(0, 3)
+	assert.Equal(2.0, min)
+	assert.Equal(8.0, max)
+
+	assert.IsNil(minTree.Set(1, 7))
+	assert.IsNil(maxTree.Set(1, 13))
+
+	min, _ = minTree.Query(0, 3)
+	max, _ = maxTree.Query(0, 3)
+	assert.Equal(4.0, min)
+	assert.Equal(13.0, max)
+
+	min, _ = minTree.Query(2, 4)
+	assert.Equal(1.0, min)
+
+	v, _ := minTree.Get(1)
+	assert.Equal(7.0, v)
+	assert.Equal([]float64{4, 7, 8, 6, 1}, minTree.Data())
+
+	// range add needs arithmetic, only a sum segment tree supports it
+	assert.IsNotNil(minTree.RangeAdd(1, 2, 5))
+	assert.IsNotNil(maxTree.Add(4, 10))
+
+	assert.IsNil(minTree.RangeSet(0, 2, 3))
+	assert.IsNil(maxTree.RangeSet(1, 3, 0))
+	assert.Equal([]float64{3, 3, 3, 6, 1}, minTree.Data())
+	assert.Equal([]float64{4, 0, 0, 0, 1}, maxTree.Data())
+
+	min, _ = minTree.Query(0, 3)
+	max, _ = maxTree.Query(1, 4)
+	assert.Equal(3.0, min)
+	assert.Equal(1.0, max)
+	assert.IsNotNil(minTree.RangeSet(2, 5, 0))
+}
+
+func TestSegmentTree_Comparator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_Comparator")
+
+	words := []string{"pear", "fig", "banana", "kiwi"}
+	byLength := NewMaxSegmentTree(words, &lengthComparator{})
+
+	longest, err := byLength.Query(0, 3)
+	assert.IsNil(err)
+	assert.Equal("banana", longest)
+
+	longest, _ = byLength.Query(0, 1)
+	assert.Equal("pear", longest)
+}
+
+type lengthComparator struct{}
+
+func (c *lengthComparator) Compare(v1, v2 any) int {
+	len1, len2 := len(v1.(string)), len(v2.(string))
+
+	if len1 < len2 {
+		return -1
+	} else if len1 > len2 {
+		return 1
+	}
+	return 0
+}
+
+func TestSegmentTree_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_JSON")
+
+	st := NewSumSegmentTree([]int{1, 2, 3})
+	data, err := json.Marshal(st)
+	assert.IsNil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	sum := NewSumSegmentTree([]int{9})
+	assert.IsNil(json.Unmarshal([]byte("[4,5]"), sum))
+	assert.IsNil(sum.RangeAdd(0, 1, 1))
+	result, _ := sum.Query(0, 1)
+	assert.Equal(11, result)
+
+	min := NewMinSegmentTree([]float64{0}, &float64Comparator{})
+	assert.IsNil(json.Unmarshal([]byte("[3,1,2]"), min))
+	minimum, _ := min.Query(0, 2)
+	assert.Equal(1.0, minimum)
+
+	assert.IsNotNil(json.Unmarshal(data, &SegmentTree[int]{}))
+}
+
+func TestSegmentTree_RandomSum(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_RandomSum")
+
+	r := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 20; round++ {
+		expected := make([]int, 1+r.Intn(50))
+		for i := range expected {
+			expected[i] = r.Intn(100) - 50
+		}
+		st := NewSumSegmentTree(append([]int{}, expected...))
+
+		for op := 0; op < 200; op++ {
+			left := r.Intn(len(expected))
+			right := left + r.Intn(len(expected)-left)
+			value := r.Intn(100) - 50
+
+			switch r.Intn(4) {
+			case 0:
+				assert.IsNil(st.Set(left, value))
+				expected[left] = value
+			case 1:
+				assert.IsNil(st.RangeAdd(left, right, value))
+				for i := left; i <= right; i++ {
+					expected[i] += value
+				}
+			case 2:
+				assert.IsNil(st.RangeSet(left, right, value))
+				for i := left; i <= right; i++ {
+					expected[i] = value
+				}
+			default:
+				sum := 0
+				for i := left; i <= right; i++ {
+					sum += expected[i]
+				}
+				actual, err := st.Query(left, right)
+				assert.IsNil(err)
+				assert.Equal(sum, actual)
+			}
+		}
+
+		assert.Equal(expected, st.Data())
+	}
+}
+
+func TestSegmentTree_RandomMinMax(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSegmentTree_RandomMinMax")
+
+	r := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 20; round++ {
+		expected := make([]float64, 1+r.Intn(50))
+		for i := range expected {
+			expected[i] = float64(r.Intn(100))
+		}
+		minTree := NewMinSegmentTree(append([]float64{}, expected...), &float64Comparator{})
+		maxTree := NewMaxSegmentTree(append([]float64{}, expected...), &float64Comparator{})
+
+		for op := 0; op < 200; op++ {
+			left := r.Intn(len(expected))
+			right := left + r.Intn(len(expected)-left)
+			value := float64(r.Intn(100))
+
+			switch r.Intn(3) {
+			case 0:
+				assert.IsNil(minTree.Set(left, value))
+				assert.IsNil(maxTree.Set(left, value))
+				expected[left] = value
+			case 1:
+				assert.IsNil(minTree.RangeSet(left, right, value))
+				assert.IsNil(maxTree.RangeSet(left, right, value))
+				for i := left; i <= right; i++ {
+					expected[i] = value
+				}
+			default:
+				min, max := expected[left], expected[left]
+				for i := left; i <= right; i++ {
+					if expected[i] < min {
+						min = expected[i]
+					}
+					if expected[i] > max {
+						max = expected[i]
+					}
+				}
+				actualMin, _ := minTree.Query(left, right)
+				actualMax, _ := maxTree.Query(left, right)
+				assert.Equal(min, actualMin)
+				assert.Equal(max, actualMax)
+			}
+		}
+
+		assert.Equal(expected, minTree.Data())
+		assert.Equal(expected, maxTree.Data())
+	}
+}
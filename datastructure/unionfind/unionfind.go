@@ -0,0 +1,213 @@
+// Package datastructure implements some data structure. eg. list, linklist, stack, queue, tree, graph.
+package datastructure
+
+import (
+	"encoding/json"
+
+	"github.com/serialt/lancet/datastructure"
+	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
+)
+
+var _ datastructure.Collection[int] = (*UnionFind[int])(nil)
+
+// UnionFind is a disjoint-set data structure, it uses path compression and union by rank,
+// so every operation runs in nearly constant amortized time.
+type UnionFind[T comparable] struct {
+	index  map[T]int
+	items  []T
+	parent []int
+	rank   []int
+	size   []int
+	count  int
+}
+
+// NewUnionFind return a UnionFind pointer, every item is put in its own set
+func NewUnionFind[T comparable](items ...T) *UnionFind[T] {
+	uf := &UnionFind[T]{index: make(map[T]int)}
+	for _, item := range items {
+		uf.Add(item)
+	}
+
+	return uf
+}
+
+// Add put item in a new set of its own, returns false if item already exists
+func (uf *UnionFind[T]) Add(item T) bool {
+	if _, ok := uf.index[item]; ok {
+		return false
+	}
+
+	i := len(uf.items)
+	uf.index[item] = i
+	uf.items = append(uf.items, item)
+	uf.parent = append(uf.parent, i)
+	uf.rank = append(uf.rank, 0)
+	uf.size = append(uf.size, 1)
+	uf.count++
+
+	return true
+}
+
+// Contain checks if item exists or not
+func (uf *UnionFind[T]) Contain(item T) bool {
+	_, ok := uf.index[item]
+	return ok
+}
+
+// Find returns the representative item of the set which item belongs to,
+// returns zero value and false if item does not exist
+func (uf *UnionFind[T]) Find(item T) (T, bool) {
+	i, ok := uf.index[item]
+	if !ok {
+		var zeroValue T
+		return zeroValue, false
+	}
+
+	return uf.items[uf.find(i)], true
+}
+
+// Union merges the sets which a and b belong to, items not existing are added first.
+// returns false if a and b are already in the same set
+func (uf *UnionFind[T]) Union(a, b T) bool {
+	uf.Add(a)
+	uf.Add(b)
+
+	rootA := uf.find(uf.index[a])
+	rootB := uf.find(uf.index[b])
+	if rootA == rootB {
+		return false
+	}
+
+	if uf.rank[rootA] < uf.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	uf.parent[rootB] = rootA
+	uf.size[rootA] += uf.size[rootB]
+	if uf.rank[rootA] == uf.rank[rootB] {
+		uf.rank[rootA]++
+	}
+	uf.count--
+
+	return true
+}
+
+// Connected checks if a and b are in the same set or not
+func (uf *UnionFind[T]) Connected(a, b T) bool {
+	i, ok := uf.index[a]
+	if !ok {
+		return false
+	}
+	j, ok := uf.index[b]
+	if !ok {
+		return false
+	}
+
+	return uf.find(i) == uf.find(j)
+}
+
+// SetSize returns the number of items in the set which item belongs to, 0 if item does not exist
+func (uf *UnionFind[T]) SetSize(item T) int {
+	i, ok := uf.index[item]
+	if !ok {
+		return 0
+	}
+
+	return uf.size[uf.find(i)]
+}
+
+// Size returns the number of items
+func (uf *UnionFind[T]) Size() int {
+	return len(uf.items)
+}
+
+// IsEmpty checks if there is no item or not
+func (uf *UnionFind[T]) IsEmpty() bool {
+	return len(uf.items) == 0
+}
+
+// Values returns all items in the order they were added
+func (uf *UnionFind[T]) Values() []T {
+	values := make([]T, len(uf.items))
+	copy(values, uf.items)
+	return values
+}
+
+// Contains checks if item exists or not
+func (uf *UnionFind[T]) Contains(item T) bool {
+	return uf.Contain(item)
+}
+
+// Iterator returns an iterator over a snapshot of all items, in the order they were added
+func (uf *UnionFind[T]) Iterator() iterator.Iterator[T] {
+	return iterator.FromSlice(uf.Values())
+}
+
+// String returns all disjoint sets, eg. [[1 2] [3]]
+func (uf *UnionFind[T]) String() string {
+	return internal.FormatValues(uf.Groups())
+}
+
+// MarshalJSON encodes all disjoint sets as a json array of arrays
+func (uf *UnionFind[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uf.Groups())
+}
+
+// UnmarshalJSON decodes a json array of arrays and replaces the disjoint sets with them, items of an array are put in one set
+func (uf *UnionFind[T]) UnmarshalJSON(data []byte) error {
+	var groups [][]T
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return err
+	}
+
+	decoded := NewUnionFind[T]()
+	for _, group := range groups {
+		for _, item := range group {
+			decoded.Union(group[0], item)
+		}
+	}
+
+	*uf = *decoded
+
+	return nil
+}
+
+// Count returns the number of disjoint sets
+func (uf *UnionFind[T]) Count() int {
+	return uf.count
+}
+
+// Groups returns all disjoint sets, both sets and items in each set are in the order items were added.
+func (uf *UnionFind[T]) Groups() [][]T {
+	groups := make([][]T, 0, uf.count)
+	groupIndex := make(map[int]int, uf.count)
+
+	for i, item := range uf.items {
+		root := uf.find(i)
+		gi, ok := groupIndex[root]
+		if !ok {
+			gi = len(groups)
+			groupIndex[root] = gi
+			groups = append(groups, make([]T, 0, uf.size[root]))
+		}
+		groups[gi] = append(groups[gi], item)
+	}
+
+	return groups
+}
+
+// find returns root index of i, and compresses the path from i to root
+func (uf *UnionFind[T]) find(i int) int {
+	root := i
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+
+	for uf.parent[i] != root {
+		next := uf.parent[i]
+		uf.parent[i] = root
+		i = next
+	}
+
+	return root
+}
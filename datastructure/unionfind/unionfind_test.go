@@ -0,0 +1,78 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestUnionFind_Union(t *testing.T) {
+	assert := internal.NewAssert(t, "TestUnionFind_Union")
+
+	uf := NewUnionFind(1, 2, 3, 4, 5)
+	assert.Equal(5, uf.Count())
+
+	assert.Equal(true, uf.Union(1, 2))
+	assert.Equal(true, uf.Union(3, 4))
+	assert.Equal(true, uf.Union(2, 4))
+	assert.Equal(false, uf.Union(1, 3))
+
+	assert.Equal(2, uf.Count())
+	assert.Equal(true, uf.Connected(1, 4))
+	assert.Equal(false, uf.Connected(1, 5))
+	assert.Equal(false, uf.Connected(1, 6))
+	assert.Equal(4, uf.SetSize(3))
+	assert.Equal(1, uf.SetSize(5))
+	assert.Equal(0, uf.SetSize(6))
+}
+
+func TestUnionFind_Find(t *testing.T) {
+	assert := internal.NewAssert(t, "TestUnionFind_Find")
+
+	uf := NewUnionFind[string]()
+	uf.Union("a", "b")
+	uf.Union("c", "b")
+
+	rootA, ok := uf.Find("a")
+	assert.Equal(true, ok)
+	rootC, _ := uf.Find("c")
+	assert.Equal(rootA, rootC)
+
+	_, ok = uf.Find("d")
+	assert.Equal(false, ok)
+
+	assert.Equal(true, uf.Add("d"))
+	assert.Equal(false, uf.Add("d"))
+	assert.Equal(true, uf.Contain("d"))
+	assert.Equal(4, uf.Size())
+}
+
+func TestUnionFind_Groups(t *testing.T) {
+	assert := internal.NewAssert(t, "TestUnionFind_Groups")
+
+	uf := NewUnionFind("a", "b", "c", "d", "e")
+	uf.Union("e", "a")
+	uf.Union("b", "d")
+
+	assert.Equal([][]string{{"a", "e"}, {"b", "d"}, {"c"}}, uf.Groups())
+}
+
+func TestUnionFind_JSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestUnionFind_JSON")
+
+	uf := NewUnionFind(1, 2, 3)
+	uf.Union(1, 3)
+
+	data, err := uf.MarshalJSON()
+	assert.IsNil(err)
+	assert.Equal("[[1,3],[2]]", string(data))
+	assert.Equal("[[1 3] [2]]", uf.String())
+	assert.Equal([]int{1, 2, 3}, uf.Values())
+
+	decoded := NewUnionFind(4)
+	assert.IsNil(decoded.UnmarshalJSON(data))
+	assert.Equal(uf.Groups(), decoded.Groups())
+	assert.Equal(2, decoded.Count())
+	assert.Equal(false, decoded.Contain(4))
+	assert.Equal(true, decoded.Connected(1, 3))
+}
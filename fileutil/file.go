@@ -19,6 +19,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/serialt/lancet/iterator"
 	"github.com/serialt/lancet/strutil"
 )
 
@@ -588,6 +589,52 @@ func GetFilepaths(dir string) ([]string, error) {
 	return paths, err
 }
 
+// WalkFiles returns an iterator over the paths of all files in a directory tree, in lexical order.
+// Unlike GetFilepaths, directories are read lazily when the iteration reaches them,
+// and the iteration stops at the first error, which is reported by Err of the iterator.
+func WalkFiles(dir string) iterator.ErrIterator[string] {
+	type walkItem struct {
+		path  string
+		isDir bool
+	}
+
+	var pending []walkItem
+	started := false
+
+	return iterator.FromFunc(func() (string, error) {
+		if !started {
+			started = true
+			info, err := os.Lstat(dir)
+			if err != nil {
+				return "", err
+			}
+			pending = append(pending, walkItem{path: dir, isDir: info.IsDir()})
+		}
+
+		for len(pending) > 0 {
+			item := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			if !item.isDir {
+				return item.path, nil
+			}
+
+			entries, err := os.ReadDir(item.path)
+			if err != nil {
+				return "", err
+			}
+			for i := len(entries) - 1; i >= 0; i-- {
+				pending = append(pending, walkItem{
+					path:  filepath.Join(item.path, entries[i].Name()),
+					isDir: entries[i].IsDir(),
+				})
+			}
+		}
+
+		return "", io.EOF
+	})
+}
+
 // FileLoopDirs 遍历目录下的所有子目录，即返回pathname下面的所有目录，目录为绝对路径
 func FileLoopDirs(pathname string) ([]string, error) {
 	var s []string
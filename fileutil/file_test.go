@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/iterator"
 )
 
 func TestIsExist(t *testing.T) {
@@ -246,3 +247,22 @@ func TestCurrentPath(t *testing.T) {
 	absPath := CurrentPath()
 	t.Log(absPath)
 }
+
+func TestWalkFiles(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWalkFiles")
+
+	iter := WalkFiles("../formatter/")
+	item, ok := iter.Next()
+	assert.Equal("../formatter/byte.go", item)
+	assert.Equal(true, ok)
+
+	paths, err := GetFilepaths("../formatter/")
+	assert.IsNil(err)
+	assert.Equal(paths[1:], iterator.ToSlice[string](iter))
+	assert.IsNil(iter.Err())
+
+	iter = WalkFiles("./not-exist-dir")
+	_, ok = iter.Next()
+	assert.Equal(false, ok)
+	assert.IsNotNil(iter.Err())
+}
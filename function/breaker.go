@@ -0,0 +1,417 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is returned by CircuitBreaker when it rejects a call without running it.
+// A retry loop around the breaker should stop on it, e.g. with retry.StopOn, rather than retry against an open breaker.
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through and counts their failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all calls with ErrBreakerOpen until the open timeout elapses.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of trial calls through to decide whether to close or open again.
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerCounts is the number of calls recorded by CircuitBreaker in its rolling window.
+type BreakerCounts struct {
+	Requests            int
+	Successes           int
+	Failures            int
+	ConsecutiveFailures int
+}
+
+// BreakerOption is for adding circuit breaker config.
+type BreakerOption func(*breakerConfig)
+
+type breakerConfig struct {
+	consecutiveFailures int
+	failureRate         float64
+	minRequests         int
+	window              time.Duration
+	buckets             int
+	openTimeout         time.Duration
+	halfOpenCalls       int
+	isFailure           func(error) bool
+	onStateChange       func(from, to BreakerState)
+	clock               Clock
+}
+
+// BreakerConsecutiveFailures set the number of consecutive failures which trips the breaker, default is 5.
+// 0 disables this trip condition.
+func BreakerConsecutiveFailures(n int) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.consecutiveFailures = n
+	}
+}
+
+// BreakerFailureRate trips the breaker when the ratio of failures in the rolling window reaches rate (0, 1],
+// once the window holds at least minRequests calls. It is disabled by default.
+func BreakerFailureRate(rate float64, minRequests int) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.failureRate = rate
+		bc.minRequests = minRequests
+	}
+}
+
+// BreakerWindow set the length of the rolling window and the number of buckets it is split into, default is 10s and 10 buckets.
+func BreakerWindow(window time.Duration, buckets int) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.window = window
+		bc.buckets = buckets
+	}
+}
+
+// BreakerOpenTimeout set how long the breaker stays open before it lets trial calls through, default is 30s.
+func BreakerOpenTimeout(d time.Duration) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.openTimeout = d
+	}
+}
+
+// BreakerHalfOpenCalls set the number of trial calls allowed in the half-open state,
+// the breaker closes once all of them succeed, default is 1.
+func BreakerHalfOpenCalls(n int) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.halfOpenCalls = n
+	}
+}
+
+// BreakerIsFailure set the function deciding whether an error counts as a failure, default counts every error
+// except context.Canceled and ErrBulkheadFull. An error which is not a failure is not recorded at all.
+func BreakerIsFailure(isFailure func(err error) bool) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.isFailure = isFailure
+	}
+}
+
+// BreakerOnStateChange set the callback invoked after the breaker changes its state.
+func BreakerOnStateChange(onStateChange func(from, to BreakerState)) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.onStateChange = onStateChange
+	}
+}
+
+// BreakerClock set the clock of the breaker, default is the system clock.
+func BreakerClock(clock Clock) BreakerOption {
+	return func(bc *breakerConfig) {
+		bc.clock = clock
+	}
+}
+
+// breakerOutcome is the result of a call let through by CircuitBreaker.
+type breakerOutcome int
+
+const (
+	outcomeSuccess breakerOutcome = iota
+	outcomeFailure
+	// outcomeIgnored is an error which is not a failure, it proves nothing about the dependency.
+	outcomeIgnored
+)
+
+type breakerBucket struct {
+	id        int64
+	successes int
+	failures  int
+}
+
+// CircuitBreaker stops calling a failing dependency. It trips from closed to open when the failures reach the
+// configured conditions, rejects calls while open, and after the open timeout lets trial calls through in the
+// half-open state to decide whether to close again. It is safe for concurrent use.
+type CircuitBreaker[T any] struct {
+	config breakerConfig
+
+	mu                sync.Mutex
+	state             BreakerState
+	generation        uint64
+	openedAt          time.Time
+	epoch             time.Time
+	buckets           []breakerBucket
+	consecutive       int
+	halfOpenRunning   int
+	halfOpenSucceeded int
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.
+func NewCircuitBreaker[T any](opts ...BreakerOption) *CircuitBreaker[T] {
+	config := breakerConfig{
+		consecutiveFailures: 5,
+		window:              10 * time.Second,
+		buckets:             10,
+		openTimeout:         30 * time.Second,
+		halfOpenCalls:       1,
+		isFailure: func(err error) bool {
+			return !errors.Is(err, context.Canceled) && !errors.Is(err, ErrBulkheadFull)
+		},
+		clock: realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.window <= 0 || config.buckets <= 0 {
+		panic("NewCircuitBreaker: window and buckets should be positive")
+	}
+	if config.halfOpenCalls <= 0 {
+		panic("NewCircuitBreaker: half-open calls should be positive")
+	}
+
+	return &CircuitBreaker[T]{
+		config:  config,
+		epoch:   config.clock.Now(),
+		buckets: make([]breakerBucket, config.buckets),
+	}
+}
+
+// Execute calls fn if the breaker allows it and records the result, otherwise it returns ErrBreakerOpen.
+// A panic in fn is recorded as a failure and re-panicked.
+func (cb *CircuitBreaker[T]) Execute(ctx context.Context, fn func(ctx context.Context) (T, error)) (T, error) {
+	generation, err := cb.allow()
+	if err != nil {
+		var zeroValue T
+		return zeroValue, err
+	}
+
+	finished := false
+	defer func() {
+		if !finished {
+			cb.done(generation, outcomeFailure)
+		}
+	}()
+
+	value, err := fn(ctx)
+	finished = true
+	cb.done(generation, cb.outcome(err))
+
+	return value, err
+}
+
+// Wrap returns a function which calls fn through the breaker.
+func (cb *CircuitBreaker[T]) Wrap(fn func(ctx context.Context) (T, error)) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		return cb.Execute(ctx, fn)
+	}
+}
+
+// State returns the current state of the breaker.
+func (cb *CircuitBreaker[T]) State() BreakerState {
+	cb.mu.Lock()
+	state, changed := cb.currentState()
+	cb.mu.Unlock()
+
+	cb.notify(changed)
+	return state
+}
+
+// Counts returns the number of calls recorded in the rolling window.
+func (cb *CircuitBreaker[T]) Counts() BreakerCounts {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	counts := cb.windowCounts()
+	counts.ConsecutiveFailures = cb.consecutive
+	return counts
+}
+
+// Reset closes the breaker and clears its counts.
+func (cb *CircuitBreaker[T]) Reset() {
+	cb.mu.Lock()
+	changed := cb.setState(BreakerClosed)
+	cb.mu.Unlock()
+
+	cb.notify(changed)
+}
+
+func (cb *CircuitBreaker[T]) allow() (uint64, error) {
+	cb.mu.Lock()
+	state, changed := cb.currentState()
+
+	var err error
+	switch state {
+	case BreakerOpen:
+		err = ErrBreakerOpen
+	case BreakerHalfOpen:
+		if cb.halfOpenRunning+cb.halfOpenSucceeded >= cb.config.halfOpenCalls {
+			err = ErrBreakerOpen
+		} else {
+			cb.halfOpenRunning++
+		}
+	}
+	generation := cb.generation
+	cb.mu.Unlock()
+
+	cb.notify(changed)
+	return generation, err
+}
+
+func (cb *CircuitBreaker[T]) outcome(err error) breakerOutcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case cb.config.isFailure(err):
+		return outcomeFailure
+	default:
+		return outcomeIgnored
+	}
+}
+
+func (cb *CircuitBreaker[T]) done(generation uint64, outcome breakerOutcome) {
+	cb.mu.Lock()
+	// the result of a call started before the last state change is ignored
+	if generation != cb.generation {
+		cb.mu.Unlock()
+		return
+	}
+
+	var changed []BreakerState
+	switch cb.state {
+	case BreakerClosed:
+		if outcome == outcomeIgnored {
+			break
+		}
+		cb.record(outcome == outcomeFailure)
+		if outcome == outcomeFailure && cb.shouldTrip() {
+			changed = cb.setState(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		// an ignored call only gives its trial slot back
+		cb.halfOpenRunning--
+		switch outcome {
+		case outcomeFailure:
+			changed = cb.setState(BreakerOpen)
+		case outcomeSuccess:
+			cb.halfOpenSucceeded++
+			if cb.halfOpenSucceeded >= cb.config.halfOpenCalls {
+				changed = cb.setState(BreakerClosed)
+			}
+		}
+	}
+	cb.mu.Unlock()
+
+	cb.notify(changed)
+}
+
+// currentState moves an open breaker to half-open once the open timeout elapses, it should be called with cb.mu held.
+func (cb *CircuitBreaker[T]) currentState() (BreakerState, []BreakerState) {
+	var changed []BreakerState
+	if cb.state == BreakerOpen && !cb.config.clock.Now().Before(cb.openedAt.Add(cb.config.openTimeout)) {
+		changed = cb.setState(BreakerHalfOpen)
+	}
+	return cb.state, changed
+}
+
+// setState should be called with cb.mu held, it returns the transition to notify.
+func (cb *CircuitBreaker[T]) setState(state BreakerState) []BreakerState {
+	from := cb.state
+
+	cb.state = state
+	cb.generation++
+	cb.halfOpenRunning = 0
+	cb.halfOpenSucceeded = 0
+
+	switch state {
+	case BreakerOpen:
+		cb.openedAt = cb.config.clock.Now()
+	case BreakerClosed:
+		cb.consecutive = 0
+		for i := range cb.buckets {
+			cb.buckets[i] = breakerBucket{}
+		}
+	}
+
+	if from == state {
+		return nil
+	}
+	return []BreakerState{from, state}
+}
+
+func (cb *CircuitBreaker[T]) notify(changed []BreakerState) {
+	if changed != nil && cb.config.onStateChange != nil {
+		cb.config.onStateChange(changed[0], changed[1])
+	}
+}
+
+// record should be called with cb.mu held.
+func (cb *CircuitBreaker[T]) record(failed bool) {
+	id := cb.bucketID()
+	bucket := &cb.buckets[id%int64(len(cb.buckets))]
+	if bucket.id != id {
+		*bucket = breakerBucket{id: id}
+	}
+
+	if failed {
+		bucket.failures++
+		cb.consecutive++
+	} else {
+		bucket.successes++
+		cb.consecutive = 0
+	}
+}
+
+// shouldTrip should be called with cb.mu held.
+func (cb *CircuitBreaker[T]) shouldTrip() bool {
+	if cb.config.consecutiveFailures > 0 && cb.consecutive >= cb.config.consecutiveFailures {
+		return true
+	}
+
+	if cb.config.failureRate > 0 {
+		counts := cb.windowCounts()
+		if counts.Requests > 0 && counts.Requests >= cb.config.minRequests &&
+			float64(counts.Failures)/float64(counts.Requests) >= cb.config.failureRate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// windowCounts should be called with cb.mu held.
+func (cb *CircuitBreaker[T]) windowCounts() BreakerCounts {
+	var counts BreakerCounts
+
+	id := cb.bucketID()
+	for _, bucket := range cb.buckets {
+		// buckets older than the window are stale
+		if bucket.id > id-int64(len(cb.buckets)) {
+			counts.Successes += bucket.successes
+			counts.Failures += bucket.failures
+		}
+	}
+	counts.Requests = counts.Successes + counts.Failures
+
+	return counts
+}
+
+func (cb *CircuitBreaker[T]) bucketID() int64 {
+	bucketSize := cb.config.window / time.Duration(len(cb.buckets))
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+	// ids start from 1, so the zero value of a bucket is always stale
+	return int64(cb.config.clock.Now().Sub(cb.epoch)/bucketSize) + 1
+}
@@ -0,0 +1,61 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serialt/lancet/retry"
+)
+
+func ExampleCircuitBreaker() {
+	cb := NewCircuitBreaker[string](
+		BreakerConsecutiveFailures(2),
+		BreakerOnStateChange(func(from, to BreakerState) {
+			fmt.Println(from, "->", to)
+		}),
+	)
+
+	fetch := cb.Wrap(func(ctx context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := fetch(context.Background())
+		fmt.Println(err)
+	}
+
+	// Output:
+	// connection refused
+	// closed -> open
+	// connection refused
+	// circuit breaker is open
+}
+
+func ExampleCircuitBreaker_retry() {
+	cb := NewCircuitBreaker[string](BreakerConsecutiveFailures(2))
+	// the bulkhead is inside the breaker, ErrBulkheadFull is not counted as a failure of the dependency
+	bulkhead := NewBulkhead[string](10)
+
+	fetch := cb.Wrap(bulkhead.Wrap(func(ctx context.Context) (string, error) {
+		return "", errors.New("timeout")
+	}))
+
+	// stop retrying once the breaker is open instead of hammering the dead dependency
+	attempts := 0
+	err := retry.Retry(func() error {
+		attempts++
+		_, err := fetch(context.Background())
+		return err
+	},
+		retry.RetryTimes(10),
+		retry.RetryDuration(time.Millisecond),
+		retry.StopOn(func(err error) bool { return errors.Is(err, ErrBreakerOpen) }),
+	)
+
+	fmt.Println(attempts, err, cb.State())
+
+	// Output:
+	// 3 circuit breaker is open open
+}
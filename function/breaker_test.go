@@ -0,0 +1,240 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+var errBreakerTest = errors.New("dependency down")
+
+func breakerCall(cb *CircuitBreaker[int], err error) (int, error) {
+	return cb.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	})
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerConsecutiveFailures")
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var transitions []string
+	cb := NewCircuitBreaker[int](
+		BreakerConsecutiveFailures(3),
+		BreakerOpenTimeout(time.Minute),
+		BreakerClock(clock),
+		BreakerOnStateChange(func(from, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+
+	breakerCall(cb, errBreakerTest)
+	breakerCall(cb, errBreakerTest)
+	breakerCall(cb, nil)
+	assert.Equal(0, cb.Counts().ConsecutiveFailures)
+	assert.Equal(BreakerClosed, cb.State())
+
+	for i := 0; i < 3; i++ {
+		breakerCall(cb, errBreakerTest)
+	}
+	assert.Equal(BreakerOpen, cb.State())
+
+	called := false
+	_, err := cb.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		called = true
+		return 0, nil
+	})
+	assert.Equal(ErrBreakerOpen, err)
+	assert.Equal(false, called)
+
+	clock.Advance(time.Minute)
+	assert.Equal(BreakerHalfOpen, cb.State())
+
+	// a failed trial call opens the breaker again
+	_, err = breakerCall(cb, errBreakerTest)
+	assert.Equal(errBreakerTest, err)
+	assert.Equal(BreakerOpen, cb.State())
+
+	clock.Advance(time.Minute)
+	v, err := breakerCall(cb, nil)
+	assert.IsNil(err)
+	assert.Equal(1, v)
+	assert.Equal(BreakerClosed, cb.State())
+	assert.Equal(0, cb.Counts().Requests)
+
+	assert.Equal([]string{
+		"closed->open", "open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed",
+	}, transitions)
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerFailureRate")
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker[int](
+		BreakerConsecutiveFailures(0),
+		BreakerFailureRate(0.5, 10),
+		BreakerWindow(10*time.Second, 10),
+		BreakerClock(clock),
+	)
+
+	// not enough requests to trip
+	for i := 0; i < 4; i++ {
+		breakerCall(cb, errBreakerTest)
+		breakerCall(cb, nil)
+	}
+	assert.Equal(BreakerClosed, cb.State())
+	assert.Equal(BreakerCounts{Requests: 8, Successes: 4, Failures: 4}, cb.Counts())
+
+	// the old calls slide out of the window
+	clock.Advance(10 * time.Second)
+	assert.Equal(0, cb.Counts().Requests)
+
+	for i := 0; i < 6; i++ {
+		breakerCall(cb, nil)
+	}
+	for i := 0; i < 3; i++ {
+		breakerCall(cb, errBreakerTest)
+	}
+	assert.Equal(BreakerClosed, cb.State())
+
+	breakerCall(cb, errBreakerTest)
+	assert.Equal(BreakerClosed, cb.State())
+
+	breakerCall(cb, errBreakerTest)
+	breakerCall(cb, errBreakerTest)
+	assert.Equal(BreakerOpen, cb.State())
+}
+
+func TestCircuitBreakerHalfOpenCalls(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerHalfOpenCalls")
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker[int](
+		BreakerConsecutiveFailures(1),
+		BreakerOpenTimeout(time.Second),
+		BreakerHalfOpenCalls(2),
+		BreakerClock(clock),
+	)
+
+	breakerCall(cb, errBreakerTest)
+	clock.Advance(time.Second)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cb.Execute(context.Background(), func(ctx context.Context) (int, error) {
+				started <- struct{}{}
+				<-release
+				return 1, nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	_, err := breakerCall(cb, nil)
+	assert.Equal(ErrBreakerOpen, err)
+	assert.Equal(BreakerHalfOpen, cb.State())
+
+	close(release)
+	wg.Wait()
+	assert.Equal(BreakerClosed, cb.State())
+}
+
+func TestCircuitBreakerIsFailure(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerIsFailure")
+
+	cb := NewCircuitBreaker[int](BreakerConsecutiveFailures(2))
+
+	// ignored errors are not recorded, neither as failures nor as successes
+	breakerCall(cb, errBreakerTest)
+	breakerCall(cb, context.Canceled)
+	breakerCall(cb, ErrBulkheadFull)
+	assert.Equal(BreakerCounts{Requests: 1, Failures: 1, ConsecutiveFailures: 1}, cb.Counts())
+	assert.Equal(BreakerClosed, cb.State())
+
+	breakerCall(cb, errBreakerTest)
+	assert.Equal(BreakerOpen, cb.State())
+
+	cb = NewCircuitBreaker[int](
+		BreakerConsecutiveFailures(1),
+		BreakerIsFailure(func(err error) bool { return !errors.Is(err, errBreakerTest) }),
+	)
+	breakerCall(cb, errBreakerTest)
+	assert.Equal(BreakerClosed, cb.State())
+
+	breakerCall(cb, errors.New("other"))
+	assert.Equal(BreakerOpen, cb.State())
+
+	cb.Reset()
+	assert.Equal(BreakerClosed, cb.State())
+}
+
+func TestCircuitBreakerHalfOpenIgnored(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerHalfOpenIgnored")
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker[int](
+		BreakerConsecutiveFailures(1),
+		BreakerOpenTimeout(time.Second),
+		BreakerClock(clock),
+	)
+
+	breakerCall(cb, errBreakerTest)
+	clock.Advance(time.Second)
+	assert.Equal(BreakerHalfOpen, cb.State())
+
+	// a cancelled trial call neither closes nor opens the breaker, it gives the trial slot back
+	_, err := breakerCall(cb, context.Canceled)
+	assert.Equal(context.Canceled, err)
+	assert.Equal(BreakerHalfOpen, cb.State())
+
+	_, err = breakerCall(cb, nil)
+	assert.IsNil(err)
+	assert.Equal(BreakerClosed, cb.State())
+}
+
+func TestCircuitBreakerPanic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerPanic")
+
+	cb := NewCircuitBreaker[int](BreakerConsecutiveFailures(1))
+
+	func() {
+		defer func() {
+			assert.Equal("boom", recover())
+		}()
+		cb.Execute(context.Background(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+	}()
+
+	assert.Equal(BreakerOpen, cb.State())
+}
+
+func TestCircuitBreakerWrap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCircuitBreakerWrap")
+
+	cb := NewCircuitBreaker[string](BreakerConsecutiveFailures(2))
+	fn := cb.Wrap(func(ctx context.Context) (string, error) {
+		return "", errBreakerTest
+	})
+
+	fn(context.Background())
+	fn(context.Background())
+	_, err := fn(context.Background())
+	assert.Equal(ErrBreakerOpen, err)
+}
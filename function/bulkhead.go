@@ -0,0 +1,137 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBulkheadFull is returned by Bulkhead when it rejects a call because too many calls are running or waiting.
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+// BulkheadOption is for adding bulkhead config.
+type BulkheadOption func(*bulkheadConfig)
+
+type bulkheadConfig struct {
+	queueSize int
+	maxWait   time.Duration
+}
+
+// BulkheadQueueSize set the number of calls allowed to wait for a free slot, default is 0,
+// which rejects a call at once if all slots are in use.
+func BulkheadQueueSize(n int) BulkheadOption {
+	return func(bc *bulkheadConfig) {
+		bc.queueSize = n
+	}
+}
+
+// BulkheadMaxWait set the max duration a call waits for a free slot before it is rejected with ErrBulkheadFull,
+// default is 0, which waits until the context is done.
+func BulkheadMaxWait(d time.Duration) BulkheadOption {
+	return func(bc *bulkheadConfig) {
+		bc.maxWait = d
+	}
+}
+
+// Bulkhead limits the number of concurrent calls to a dependency, so a slow dependency can not use up all goroutines
+// of the caller. It is safe for concurrent use.
+// When it is combined with CircuitBreaker, put the bulkhead inside the breaker: cb.Wrap(bulkhead.Wrap(fn)),
+// the default failure check of the breaker does not count ErrBulkheadFull, so local saturation does not trip it.
+type Bulkhead[T any] struct {
+	config bulkheadConfig
+	slots  chan struct{}
+
+	mu      sync.Mutex
+	waiting int
+}
+
+// NewBulkhead creates a Bulkhead which runs at most maxConcurrent calls at the same time.
+func NewBulkhead[T any](maxConcurrent int, opts ...BulkheadOption) *Bulkhead[T] {
+	if maxConcurrent <= 0 {
+		panic("NewBulkhead: max concurrent calls should be positive")
+	}
+
+	config := bulkheadConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Bulkhead[T]{
+		config: config,
+		slots:  make(chan struct{}, maxConcurrent),
+	}
+}
+
+// Execute calls fn once a slot is free. It returns ErrBulkheadFull if the queue is full or the max wait elapses,
+// and ctx.Err() if ctx is done while waiting.
+func (b *Bulkhead[T]) Execute(ctx context.Context, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zeroValue T
+
+	if err := b.acquire(ctx); err != nil {
+		return zeroValue, err
+	}
+	defer func() {
+		<-b.slots
+	}()
+
+	return fn(ctx)
+}
+
+// Wrap returns a function which calls fn through the bulkhead.
+func (b *Bulkhead[T]) Wrap(fn func(ctx context.Context) (T, error)) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		return b.Execute(ctx, fn)
+	}
+}
+
+// Running returns the number of running calls.
+func (b *Bulkhead[T]) Running() int {
+	return len(b.slots)
+}
+
+// Waiting returns the number of calls waiting for a free slot.
+func (b *Bulkhead[T]) Waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.waiting
+}
+
+func (b *Bulkhead[T]) acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	b.mu.Lock()
+	if b.waiting >= b.config.queueSize {
+		b.mu.Unlock()
+		return ErrBulkheadFull
+	}
+	b.waiting++
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.waiting--
+		b.mu.Unlock()
+	}()
+
+	var timeout <-chan time.Time
+	if b.config.maxWait > 0 {
+		timer := time.NewTimer(b.config.maxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-timeout:
+		return ErrBulkheadFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,109 @@
+package function
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestBulkhead(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBulkhead")
+
+	b := NewBulkhead[int](2)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	results := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			v, _ := b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+				started <- struct{}{}
+				<-release
+				return 1, nil
+			})
+			results <- v
+		}()
+	}
+	<-started
+	<-started
+	assert.Equal(2, b.Running())
+
+	_, err := b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.Equal(ErrBulkheadFull, err)
+
+	close(release)
+	assert.Equal(1, <-results)
+	assert.Equal(1, <-results)
+	assert.Equal(0, b.Running())
+}
+
+func TestBulkheadQueue(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBulkheadQueue")
+
+	b := NewBulkhead[int](1, BulkheadQueueSize(1))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	})
+	<-started
+
+	queued := make(chan int)
+	go func() {
+		v, _ := b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+			return 2, nil
+		})
+		queued <- v
+	}()
+
+	for b.Waiting() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the queue is full
+	_, err := b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	assert.Equal(ErrBulkheadFull, err)
+
+	close(release)
+	assert.Equal(2, <-queued)
+	assert.Equal(0, b.Waiting())
+}
+
+func TestBulkheadMaxWait(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBulkheadMaxWait")
+
+	b := NewBulkhead[int](1, BulkheadQueueSize(10), BulkheadMaxWait(20*time.Millisecond))
+
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	})
+	<-started
+
+	_, err := b.Execute(context.Background(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.Equal(ErrBulkheadFull, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b = NewBulkhead[int](1, BulkheadQueueSize(10))
+	b.slots <- struct{}{}
+	_, err = b.Execute(ctx, func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.Equal(context.Canceled, err)
+}
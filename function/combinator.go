@@ -0,0 +1,170 @@
+package function
+
+// After0 creates a function that invokes fn once it's called n or more times, it reports whether fn is invoked.
+func After0(n int, fn func()) func() bool {
+	return func() bool {
+		n--
+		if n < 1 {
+			fn()
+			return true
+		}
+		return false
+	}
+}
+
+// After1 creates a function that invokes fn once it's called n or more times,
+// it returns the result of fn and reports whether fn is invoked.
+func After1[T, R any](n int, fn func(T) R) func(T) (R, bool) {
+	return func(arg T) (R, bool) {
+		n--
+		if n < 1 {
+			return fn(arg), true
+		}
+		var zeroValue R
+		return zeroValue, false
+	}
+}
+
+// After2 creates a function that invokes fn once it's called n or more times,
+// it returns the result of fn and reports whether fn is invoked.
+func After2[T1, T2, R any](n int, fn func(T1, T2) R) func(T1, T2) (R, bool) {
+	return func(arg1 T1, arg2 T2) (R, bool) {
+		n--
+		if n < 1 {
+			return fn(arg1, arg2), true
+		}
+		var zeroValue R
+		return zeroValue, false
+	}
+}
+
+// Before0 creates a function that invokes fn while it's called less than n times, later calls do nothing.
+func Before0(n int, fn func()) func() {
+	return func() {
+		if n > 0 {
+			n--
+			fn()
+		}
+	}
+}
+
+// Before1 creates a function that invokes fn while it's called less than n times,
+// later calls return the result of the last invocation.
+func Before1[T, R any](n int, fn func(T) R) func(T) R {
+	var result R
+	return func(arg T) R {
+		if n > 0 {
+			n--
+			result = fn(arg)
+		}
+		return result
+	}
+}
+
+// Before2 creates a function that invokes fn while it's called less than n times,
+// later calls return the result of the last invocation.
+func Before2[T1, T2, R any](n int, fn func(T1, T2) R) func(T1, T2) R {
+	var result R
+	return func(arg1 T1, arg2 T2) R {
+		if n > 0 {
+			n--
+			result = fn(arg1, arg2)
+		}
+		return result
+	}
+}
+
+// Partial binds the first argument of fn to arg1.
+func Partial[T1, T2, R any](fn func(T1, T2) R, arg1 T1) func(T2) R {
+	return func(arg2 T2) R {
+		return fn(arg1, arg2)
+	}
+}
+
+// Partial3 binds the first argument of fn to arg1.
+func Partial3[T1, T2, T3, R any](fn func(T1, T2, T3) R, arg1 T1) func(T2, T3) R {
+	return func(arg2 T2, arg3 T3) R {
+		return fn(arg1, arg2, arg3)
+	}
+}
+
+// Curry2 converts a function of two arguments into a chain of functions of one argument.
+func Curry2[T1, T2, R any](fn func(T1, T2) R) func(T1) func(T2) R {
+	return func(arg1 T1) func(T2) R {
+		return func(arg2 T2) R {
+			return fn(arg1, arg2)
+		}
+	}
+}
+
+// Curry3 converts a function of three arguments into a chain of functions of one argument.
+func Curry3[T1, T2, T3, R any](fn func(T1, T2, T3) R) func(T1) func(T2) func(T3) R {
+	return func(arg1 T1) func(T2) func(T3) R {
+		return func(arg2 T2) func(T3) R {
+			return func(arg3 T3) R {
+				return fn(arg1, arg2, arg3)
+			}
+		}
+	}
+}
+
+// Flip creates a function that invokes fn with its two arguments swapped.
+func Flip[T1, T2, R any](fn func(T1, T2) R) func(T2, T1) R {
+	return func(arg2 T2, arg1 T1) R {
+		return fn(arg1, arg2)
+	}
+}
+
+// Negate creates a function that negates the result of predicate.
+func Negate[T any](predicate func(T) bool) func(T) bool {
+	return func(arg T) bool {
+		return !predicate(arg)
+	}
+}
+
+// Compose2 composes two functions from right to left, Compose2(f, g)(x) is f(g(x)).
+func Compose2[A, B, C any](f func(B) C, g func(A) B) func(A) C {
+	return func(arg A) C {
+		return f(g(arg))
+	}
+}
+
+// Compose3 composes three functions from right to left, Compose3(f, g, h)(x) is f(g(h(x))).
+func Compose3[A, B, C, D any](f func(C) D, g func(B) C, h func(A) B) func(A) D {
+	return func(arg A) D {
+		return f(g(h(arg)))
+	}
+}
+
+// Compose4 composes four functions from right to left, Compose4(f, g, h, i)(x) is f(g(h(i(x)))).
+func Compose4[A, B, C, D, E any](f func(D) E, g func(C) D, h func(B) C, i func(A) B) func(A) E {
+	return func(arg A) E {
+		return f(g(h(i(arg))))
+	}
+}
+
+// Compose5 composes five functions from right to left, Compose5(f, g, h, i, j)(x) is f(g(h(i(j(x))))).
+func Compose5[A, B, C, D, E, F any](f func(E) F, g func(D) E, h func(C) D, i func(B) C, j func(A) B) func(A) F {
+	return func(arg A) F {
+		return f(g(h(i(j(arg)))))
+	}
+}
+
+// Juxt creates a function that calls every function with the same argument and returns their results in order.
+func Juxt[T, R any](fns ...func(T) R) func(T) []R {
+	return func(arg T) []R {
+		result := make([]R, len(fns))
+		for i, fn := range fns {
+			result[i] = fn(arg)
+		}
+		return result
+	}
+}
+
+// Tap creates a function that calls fn with its argument for side effects, then returns the argument.
+func Tap[T any](fn func(T)) func(T) T {
+	return func(arg T) T {
+		fn(arg)
+		return arg
+	}
+}
@@ -0,0 +1,59 @@
+package function
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ExampleCompose3() {
+	double := func(n int) int { return n * 2 }
+	exclaim := func(s string) string { return s + "!" }
+
+	fn := Compose3(exclaim, strconv.Itoa, double)
+
+	fmt.Println(fn(21))
+
+	// Output:
+	// 42!
+}
+
+func ExampleCurry2() {
+	add := func(a, b int) int { return a + b }
+
+	addTwo := Curry2(add)(2)
+
+	fmt.Println(addTwo(3))
+
+	// Output:
+	// 5
+}
+
+func ExampleFlip() {
+	repeat := Flip(strings.Repeat)
+
+	fmt.Println(repeat(3, "ab"))
+
+	// Output:
+	// ababab
+}
+
+func ExampleJuxt() {
+	fn := Juxt(strings.ToUpper, strings.ToLower)
+
+	fmt.Println(fn("Go"))
+
+	// Output:
+	// [GO go]
+}
+
+func ExampleAfter1() {
+	fn := After1(2, func(s string) string { return "done: " + s })
+
+	fmt.Println(fn("a"))
+	fmt.Println(fn("b"))
+
+	// Output:
+	//  false
+	// done: b true
+}
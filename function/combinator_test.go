@@ -0,0 +1,105 @@
+package function
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestAfterN(t *testing.T) {
+	assert := internal.NewAssert(t, "TestAfterN")
+
+	count := 0
+	f0 := After0(2, func() { count++ })
+	assert.Equal(false, f0())
+	assert.Equal(true, f0())
+	assert.Equal(true, f0())
+	assert.Equal(2, count)
+
+	f1 := After1(2, func(n int) int { return n * 2 })
+	v, ok := f1(1)
+	assert.Equal(0, v)
+	assert.Equal(false, ok)
+	v, ok = f1(2)
+	assert.Equal(4, v)
+	assert.Equal(true, ok)
+
+	f2 := After2(1, func(a, b int) int { return a + b })
+	v, ok = f2(1, 2)
+	assert.Equal(3, v)
+	assert.Equal(true, ok)
+}
+
+func TestBeforeN(t *testing.T) {
+	assert := internal.NewAssert(t, "TestBeforeN")
+
+	count := 0
+	f0 := Before0(2, func() { count++ })
+	f0()
+	f0()
+	f0()
+	assert.Equal(2, count)
+
+	f1 := Before1(2, strings.ToUpper)
+	assert.Equal("A", f1("a"))
+	assert.Equal("B", f1("b"))
+	assert.Equal("B", f1("c"))
+
+	f2 := Before2(1, func(a, b int) int { return a * b })
+	assert.Equal(6, f2(2, 3))
+	assert.Equal(6, f2(4, 5))
+}
+
+func TestPartialAndCurry(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPartialAndCurry")
+
+	add := func(a, b int) int { return a + b }
+	join := func(a, b, c string) string { return a + b + c }
+
+	assert.Equal(5, Partial(add, 2)(3))
+	assert.Equal("abc", Partial3(join, "a")("b", "c"))
+	assert.Equal(5, Curry2(add)(2)(3))
+	assert.Equal("abc", Curry3(join)("a")("b")("c"))
+}
+
+func TestFlipAndNegate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFlipAndNegate")
+
+	repeat := Flip(strings.Repeat)
+	assert.Equal("abab", repeat(2, "ab"))
+
+	isEven := func(n int) bool { return n%2 == 0 }
+	isOdd := Negate(isEven)
+	assert.Equal(true, isOdd(3))
+	assert.Equal(false, isOdd(4))
+}
+
+func TestComposeN(t *testing.T) {
+	assert := internal.NewAssert(t, "TestComposeN")
+
+	double := func(n int) int { return n * 2 }
+	toString := strconv.Itoa
+	length := func(s string) int { return len(s) }
+	isLong := func(n int) bool { return n > 2 }
+	not := func(b bool) bool { return !b }
+
+	assert.Equal("8", Compose2(toString, double)(4))
+	assert.Equal(2, Compose3(length, toString, double)(5))
+	assert.Equal(true, Compose4(isLong, length, toString, double)(50))
+	assert.Equal(false, Compose5(not, isLong, length, toString, double)(500))
+}
+
+func TestJuxtAndTap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJuxtAndTap")
+
+	stats := Juxt(strings.ToUpper, strings.ToLower, strings.TrimSpace)
+	assert.Equal([]string{" AB ", " ab ", "Ab"}, stats(" Ab "))
+
+	seen := []int{}
+	tap := Tap(func(n int) { seen = append(seen, n) })
+	assert.Equal(1, tap(1))
+	assert.Equal(2, tap(2))
+	assert.Equal([]int{1, 2}, seen)
+}
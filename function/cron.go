@@ -0,0 +1,291 @@
+package function
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule describes when a job of Scheduler runs.
+type CronSchedule interface {
+	// Next returns the next activation time which is later than t, zero time means never.
+	Next(t time.Time) time.Time
+}
+
+// cronSpec is a parsed cron expression, every field is a bit set of the allowed values.
+type cronSpec struct {
+	second, minute, hour, dom, month, dow uint64
+	// domStar and dowStar record if the day fields are unrestricted, when both are restricted a day matches either of them.
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+// everySchedule runs at a fixed interval.
+type everySchedule struct {
+	interval time.Duration
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = cronField{min: 0, max: 59}
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as sunday as well, it is folded into 0 after parsing
+	dowField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron parses a cron expression into a CronSchedule, the time zone of the schedule is time.Local.
+// It supports:
+//   - 5 fields: minute hour day-of-month month day-of-week
+//   - 6 fields: second minute hour day-of-month month day-of-week
+//   - `*`, `a-b`, `*/n`, `a-b/n`, `a/n` and comma separated lists, month and weekday names like JAN, MON
+//   - descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly and @every <duration>
+//   - a leading CRON_TZ=<zone> or TZ=<zone> to set the time zone, eg. CRON_TZ=Asia/Shanghai 0 9 * * *
+func ParseCron(expr string) (CronSchedule, error) {
+	return parseCron(expr, time.Local)
+}
+
+func parseCron(expr string, location *time.Location) (CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if spec == "" {
+		return nil, fmt.Errorf("invalid cron expression %q: empty", expr)
+	}
+
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.IndexAny(spec, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid cron expression %q: missing fields after time zone", expr)
+		}
+
+		zone := spec[strings.Index(spec, "=")+1 : i]
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		location = loc
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid cron expression %q: interval should be positive", expr)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		fields, ok := cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron expression %q: unknown descriptor", expr)
+		}
+		spec = fields
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	result := &cronSpec{location: location}
+	targets := []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&result.second, secondField},
+		{&result.minute, minuteField},
+		{&result.hour, hourField},
+		{&result.dom, domField},
+		{&result.month, monthField},
+		{&result.dow, dowField},
+	}
+
+	for i, target := range targets {
+		value, err := target.field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		*target.bits = value
+	}
+
+	if result.dow&(1<<7) != 0 {
+		result.dow = result.dow&^(1<<7) | 1
+	}
+	result.domStar = fields[3] == "*" || fields[3] == "?"
+	result.dowStar = fields[5] == "*" || fields[5] == "?"
+
+	return result, nil
+}
+
+// parse parses a comma separated list of ranges into a bit set.
+func (f cronField) parse(expr string) (uint64, error) {
+	var result uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		value, err := f.parseRange(part)
+		if err != nil {
+			return 0, err
+		}
+		result |= value
+	}
+
+	return result, nil
+}
+
+func (f cronField) parseRange(expr string) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepExpr)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step %q", expr)
+		}
+		step = n
+	}
+
+	var start, end int
+	switch {
+	case rangeExpr == "*" || rangeExpr == "?":
+		start, end = f.min, f.max
+	case strings.Contains(rangeExpr, "-"):
+		lowExpr, highExpr, _ := strings.Cut(rangeExpr, "-")
+		low, err := f.parseValue(lowExpr)
+		if err != nil {
+			return 0, err
+		}
+		high, err := f.parseValue(highExpr)
+		if err != nil {
+			return 0, err
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range %q", expr)
+		}
+		start, end = low, high
+	default:
+		value, err := f.parseValue(rangeExpr)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// a/n means from a to the max value
+		if hasStep {
+			end = f.max
+		}
+	}
+
+	var result uint64
+	for i := start; i <= end; i += step {
+		result |= 1 << uint(i)
+	}
+
+	return result, nil
+}
+
+func (f cronField) parseValue(expr string) (int, error) {
+	if value, ok := f.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, f.min, f.max)
+	}
+
+	return value, nil
+}
+
+// Next returns the next time matching the expression which is later than t, or zero time if there is none in five years.
+func (s *cronSpec) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	t = t.In(s.location).Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			// a daylight saving transition may map the next hour back to the current one
+			if !next.After(t) {
+				next = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+			}
+			t = next
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+		if !has(s.second, t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+
+		return t.In(origLocation)
+	}
+
+	return time.Time{}
+}
+
+func (s *cronSpec) matchDay(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// Next returns t plus the interval.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// String returns the interval as a @every descriptor.
+func (s everySchedule) String() string {
+	return "@every " + s.interval.String()
+}
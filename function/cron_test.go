@@ -0,0 +1,87 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParseCron_Next")
+
+	date := func(year int, month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"0 9 * * *", date(2024, 1, 1, 10, 0, 0), date(2024, 1, 2, 9, 0, 0)},
+		{"*/15 * * * *", date(2024, 1, 1, 0, 7, 0), date(2024, 1, 1, 0, 15, 0)},
+		{"0 0 1 * *", date(2024, 1, 15, 0, 0, 0), date(2024, 2, 1, 0, 0, 0)},
+		{"0 0 * * MON", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 8, 0, 0, 0)},
+		{"0 0 * * 7", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 7, 0, 0, 0)},
+		{"0 0 13 * FRI", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 5, 0, 0, 0)},
+		{"0 0 29 FEB *", date(2024, 3, 1, 0, 0, 0), date(2028, 2, 29, 0, 0, 0)},
+		{"0 8-18/4 * * 1-5", date(2024, 1, 6, 0, 0, 0), date(2024, 1, 8, 8, 0, 0)},
+		{"5,10 0 * * *", date(2024, 1, 1, 0, 5, 0), date(2024, 1, 1, 0, 10, 0)},
+		{"30 * * * * *", date(2024, 1, 1, 0, 0, 10), date(2024, 1, 1, 0, 0, 30)},
+		{"10/20 * * * * *", date(2024, 1, 1, 0, 0, 11), date(2024, 1, 1, 0, 0, 30)},
+		{"@daily", date(2024, 1, 1, 12, 0, 0), date(2024, 1, 2, 0, 0, 0)},
+		{"@hourly", date(2024, 1, 1, 12, 0, 0), date(2024, 1, 1, 13, 0, 0)},
+		{"@every 90s", date(2024, 1, 1, 12, 0, 0), date(2024, 1, 1, 12, 1, 30)},
+		{"CRON_TZ=Asia/Shanghai 0 9 * * *", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 1, 1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := parseCron(tt.expr, time.UTC)
+		assert.IsNil(err)
+
+		next := schedule.Next(tt.from)
+		if !next.Equal(tt.expected) {
+			t.Errorf("%s: Next(%v) = %v, expected %v", tt.expr, tt.from, next, tt.expected)
+		}
+	}
+}
+
+func TestParseCron_Location(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParseCron_Location")
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.IsNil(err)
+
+	schedule, err := parseCron("0 0 9 * * *", shanghai)
+	assert.IsNil(err)
+
+	next := schedule.Next(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC))
+	assert.Equal(time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), next.UTC())
+	// the result keeps the location of the argument
+	assert.Equal(time.UTC, next.Location())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"@foo",
+		"@every -1s",
+		"@every abc",
+		"CRON_TZ=Nowhere/Nothing * * * * *",
+		"CRON_TZ=UTC",
+	}
+
+	for _, expr := range invalid {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
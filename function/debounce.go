@@ -0,0 +1,187 @@
+package function
+
+import (
+	"sync"
+	"time"
+)
+
+// DebounceOption is for adding debounce config.
+type DebounceOption func(*debounceConfig)
+
+type debounceConfig struct {
+	leading  bool
+	trailing bool
+	maxWait  time.Duration
+}
+
+// DebounceLeading set whether fn is invoked on the leading edge of the wait duration, default is false.
+func DebounceLeading(leading bool) DebounceOption {
+	return func(dc *debounceConfig) {
+		dc.leading = leading
+	}
+}
+
+// DebounceTrailing set whether fn is invoked on the trailing edge of the wait duration, default is true.
+func DebounceTrailing(trailing bool) DebounceOption {
+	return func(dc *debounceConfig) {
+		dc.trailing = trailing
+	}
+}
+
+// DebounceMaxWait set the max duration fn is allowed to be delayed before it is invoked.
+func DebounceMaxWait(maxWait time.Duration) DebounceOption {
+	return func(dc *debounceConfig) {
+		dc.maxWait = maxWait
+	}
+}
+
+// Debouncer delays invoking fn until wait duration have elapsed since the last call, it does not keep any goroutine
+// while there is no pending invocation. It is safe for concurrent use.
+type Debouncer[T any] struct {
+	fn     func(T)
+	wait   time.Duration
+	config debounceConfig
+
+	mu          sync.Mutex
+	timer       *time.Timer
+	generation  uint64
+	arg         T
+	pending     bool
+	called      bool
+	lastCall    time.Time
+	maxDeadline time.Time
+}
+
+// Debounce creates a Debouncer which delays invoking fn until after wait duration have elapsed since the last call,
+// fn is invoked with the argument of the last call.
+func Debounce[T any](fn func(arg T), wait time.Duration, opts ...DebounceOption) *Debouncer[T] {
+	if fn == nil {
+		panic("Debounce: fn should not be nil")
+	}
+
+	config := debounceConfig{trailing: true}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Debouncer[T]{fn: fn, wait: wait, config: config}
+}
+
+// Throttle creates a Debouncer which invokes fn at most once per interval, the first call is invoked at once,
+// and the calls during the interval are merged into one trailing invocation with the argument of the last call.
+func Throttle[T any](fn func(arg T), interval time.Duration) *Debouncer[T] {
+	return Debounce(fn, interval, DebounceLeading(true), DebounceTrailing(true), DebounceMaxWait(interval))
+}
+
+// Call requests an invocation of fn with arg.
+func (d *Debouncer[T]) Call(arg T) {
+	d.mu.Lock()
+
+	now := time.Now()
+	idle := !d.called || now.Sub(d.lastCall) >= d.wait
+	d.called = true
+	d.lastCall = now
+	d.arg = arg
+	d.pending = true
+
+	invoke := false
+	if d.timer == nil {
+		if d.config.maxWait > 0 {
+			d.maxDeadline = now.Add(d.config.maxWait)
+		}
+		if idle && d.config.leading {
+			invoke = true
+			d.pending = false
+		}
+	}
+	d.schedule(now)
+
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn(arg)
+	}
+}
+
+// Cancel drops the pending invocation.
+func (d *Debouncer[T]) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stop()
+	d.pending = false
+	d.called = false
+}
+
+// Flush invokes the pending invocation at once, it does nothing if there is no pending invocation.
+func (d *Debouncer[T]) Flush() {
+	d.mu.Lock()
+
+	if d.timer == nil || !d.pending {
+		d.mu.Unlock()
+		return
+	}
+
+	d.stop()
+	arg := d.arg
+	d.pending = false
+
+	d.mu.Unlock()
+
+	d.fn(arg)
+}
+
+// Pending checks if there is a pending invocation or not.
+func (d *Debouncer[T]) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.timer != nil && d.pending
+}
+
+// schedule restarts the timer, it should be called with d.mu held.
+func (d *Debouncer[T]) schedule(now time.Time) {
+	delay := d.wait
+	if d.config.maxWait > 0 {
+		if untilMax := d.maxDeadline.Sub(now); untilMax < delay {
+			delay = untilMax
+		}
+	}
+
+	d.stop()
+
+	generation := d.generation
+	d.timer = time.AfterFunc(delay, func() {
+		d.expire(generation)
+	})
+}
+
+// stop stops the timer, it should be called with d.mu held.
+func (d *Debouncer[T]) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	// a timer which has fired but not taken the lock yet is ignored
+	d.generation++
+}
+
+func (d *Debouncer[T]) expire(generation uint64) {
+	d.mu.Lock()
+
+	if generation != d.generation {
+		d.mu.Unlock()
+		return
+	}
+
+	d.timer = nil
+	invoke := d.config.trailing && d.pending
+	arg := d.arg
+	d.pending = false
+
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn(arg)
+	}
+}
@@ -0,0 +1,41 @@
+package function
+
+import (
+	"fmt"
+	"time"
+)
+
+func ExampleDebounce() {
+	debouncer := Debounce(func(s string) {
+		fmt.Println(s)
+	}, 10*time.Millisecond)
+
+	debouncer.Call("a")
+	debouncer.Call("b")
+	debouncer.Call("c")
+
+	time.Sleep(30 * time.Millisecond)
+
+	debouncer.Call("d")
+	debouncer.Flush()
+
+	// Output:
+	// c
+	// d
+}
+
+func ExampleThrottle() {
+	throttled := Throttle(func(n int) {
+		fmt.Println(n)
+	}, 20*time.Millisecond)
+
+	throttled.Call(1)
+	throttled.Call(2)
+	throttled.Call(3)
+
+	time.Sleep(40 * time.Millisecond)
+
+	// Output:
+	// 1
+	// 3
+}
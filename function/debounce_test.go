@@ -0,0 +1,119 @@
+package function
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []int
+}
+
+func (r *callRecorder) record(n int) {
+	r.mu.Lock()
+	r.calls = append(r.calls, n)
+	r.mu.Unlock()
+}
+
+func (r *callRecorder) get() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int{}, r.calls...)
+}
+
+func TestDebounce_Trailing(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDebounce_Trailing")
+
+	recorder := &callRecorder{}
+	debouncer := Debounce(recorder.record, 20*time.Millisecond)
+
+	for i := 1; i <= 3; i++ {
+		debouncer.Call(i)
+	}
+	assert.Equal(true, debouncer.Pending())
+	assert.Equal([]int{}, recorder.get())
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal([]int{3}, recorder.get())
+	assert.Equal(false, debouncer.Pending())
+}
+
+func TestDebounce_Leading(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDebounce_Leading")
+
+	recorder := &callRecorder{}
+	debouncer := Debounce(recorder.record, 20*time.Millisecond, DebounceLeading(true), DebounceTrailing(false))
+
+	debouncer.Call(1)
+	debouncer.Call(2)
+	debouncer.Call(3)
+	assert.Equal([]int{1}, recorder.get())
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal([]int{1}, recorder.get())
+
+	debouncer.Call(4)
+	assert.Equal([]int{1, 4}, recorder.get())
+}
+
+func TestDebounce_MaxWait(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDebounce_MaxWait")
+
+	recorder := &callRecorder{}
+	debouncer := Debounce(recorder.record, 30*time.Millisecond, DebounceMaxWait(50*time.Millisecond))
+
+	// calls keep coming faster than wait, maxWait forces invocations
+	for i := 1; i <= 12; i++ {
+		debouncer.Call(i)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	calls := recorder.get()
+	assert.Equal(true, len(calls) >= 2)
+	assert.Equal(12, calls[len(calls)-1])
+}
+
+func TestDebounce_CancelAndFlush(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDebounce_CancelAndFlush")
+
+	recorder := &callRecorder{}
+	debouncer := Debounce(recorder.record, 20*time.Millisecond)
+
+	debouncer.Call(1)
+	debouncer.Cancel()
+	assert.Equal(false, debouncer.Pending())
+
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal([]int{}, recorder.get())
+
+	debouncer.Call(2)
+	debouncer.Flush()
+	assert.Equal([]int{2}, recorder.get())
+
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal([]int{2}, recorder.get())
+
+	// flush without pending invocation does nothing
+	debouncer.Flush()
+	assert.Equal([]int{2}, recorder.get())
+}
+
+func TestThrottle(t *testing.T) {
+	assert := internal.NewAssert(t, "TestThrottle")
+
+	recorder := &callRecorder{}
+	throttled := Throttle(recorder.record, 50*time.Millisecond)
+
+	throttled.Call(1)
+	throttled.Call(2)
+	throttled.Call(3)
+	assert.Equal([]int{1}, recorder.get())
+
+	time.Sleep(80 * time.Millisecond)
+	assert.Equal([]int{1, 3}, recorder.get())
+}
@@ -12,6 +12,8 @@ import (
 
 // After creates a function that invokes func once it's called n or more times.
 // Play: https://go.dev/play/p/8mQhkFmsgqs
+//
+// Deprecated: use After0, After1 or After2 instead, they are checked at compile time and return typed results.
 func After(n int, fn any) func(args ...any) []reflect.Value {
 	// Catch programming error while constructing the closure
 	mustBeFunction(fn)
@@ -27,6 +29,8 @@ func After(n int, fn any) func(args ...any) []reflect.Value {
 
 // Before creates a function that invokes func once it's called less than n times.
 // Play: https://go.dev/play/p/0HqUDIFZ3IL
+//
+// Deprecated: use Before0, Before1 or Before2 instead, they are checked at compile time and return typed results.
 func Before(n int, fn any) func(args ...any) []reflect.Value {
 	// Catch programming error while constructing the closure
 	mustBeFunction(fn)
@@ -89,21 +93,15 @@ func Debounced(fn func(), duration time.Duration) func() {
 	// Catch programming error while constructing the closure
 	mustBeFunction(fn)
 
-	timer := time.NewTimer(duration)
-	timer.Stop()
+	debouncer := Debounce(func(struct{}) { fn() }, duration)
 
-	go func() {
-		for {
-			<-timer.C
-			go fn()
-		}
-	}()
-
-	return func() { timer.Reset(duration) }
+	return func() { debouncer.Call(struct{}{}) }
 }
 
 // Schedule invoke function every duration time, util close the returned bool channel.
 // Play: https://go.dev/play/p/hbON-Xeyn5N
+//
+// Deprecated: use Scheduler with an @every expression instead, it supports cron expressions and context based shutdown.
 func Schedule(d time.Duration, fn any, args ...any) chan bool {
 	// Catch programming error while constructing the closure
 	mustBeFunction(fn)
@@ -0,0 +1,122 @@
+package function
+
+import (
+	"sync"
+	"time"
+)
+
+// OnceValue returns a function that invokes fn only once and returns the value returned by fn.
+// If fn panics, the returned function panics with the same value on every call.
+func OnceValue[T any](fn func() T) func() T {
+	var (
+		once   sync.Once
+		result T
+		p      any
+		failed bool
+	)
+
+	return func() T {
+		once.Do(func() {
+			defer func() {
+				if failed {
+					p = recover()
+				}
+			}()
+
+			failed = true
+			result = fn()
+			failed = false
+		})
+
+		if failed {
+			panic(p)
+		}
+		return result
+	}
+}
+
+// OnceValues returns a function that invokes fn only once and returns the values returned by fn.
+// If fn panics, the returned function panics with the same value on every call.
+func OnceValues[T1, T2 any](fn func() (T1, T2)) func() (T1, T2) {
+	type pair struct {
+		v1 T1
+		v2 T2
+	}
+
+	once := OnceValue(func() pair {
+		v1, v2 := fn()
+		return pair{v1: v1, v2: v2}
+	})
+
+	return func() (T1, T2) {
+		result := once()
+		return result.v1, result.v2
+	}
+}
+
+// Memoize returns a function that caches the result of fn for every argument, fn is invoked once per argument
+// unless concurrent first calls race for the same argument. The returned function is safe for concurrent use.
+func Memoize[K comparable, V any](fn func(key K) V) func(key K) V {
+	var mu sync.RWMutex
+	cache := make(map[K]V)
+
+	return func(key K) V {
+		mu.RLock()
+		value, ok := cache[key]
+		mu.RUnlock()
+
+		if ok {
+			return value
+		}
+
+		value = fn(key)
+
+		mu.Lock()
+		cache[key] = value
+		mu.Unlock()
+
+		return value
+	}
+}
+
+// MemoizeWithTTL is like Memoize, but a cached result expires after ttl, then fn is invoked again for the argument.
+// Expired results are removed when the cache is accessed.
+func MemoizeWithTTL[K comparable, V any](fn func(key K) V, ttl time.Duration) func(key K) V {
+	type entry struct {
+		value    V
+		expireAt time.Time
+	}
+
+	var mu sync.Mutex
+	cache := make(map[K]entry)
+	nextPurge := time.Now().Add(ttl)
+
+	return func(key K) V {
+		now := time.Now()
+
+		mu.Lock()
+		if now.After(nextPurge) {
+			for k, e := range cache {
+				if !now.Before(e.expireAt) {
+					delete(cache, k)
+				}
+			}
+			nextPurge = now.Add(ttl)
+		}
+
+		e, ok := cache[key]
+		mu.Unlock()
+
+		if ok && now.Before(e.expireAt) {
+			return e.value
+		}
+
+		value := fn(key)
+
+		mu.Lock()
+		cache[key] = entry{value: value, expireAt: time.Now().Add(ttl)}
+		mu.Unlock()
+
+		return value
+	}
+}
@@ -0,0 +1,37 @@
+package function
+
+import (
+	"fmt"
+)
+
+func ExampleMemoize() {
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	fmt.Println(square(3))
+	fmt.Println(square(3))
+	fmt.Println(calls)
+
+	// Output:
+	// 9
+	// 9
+	// 1
+}
+
+func ExampleOnceValue() {
+	load := OnceValue(func() string {
+		fmt.Println("loading")
+		return "config"
+	})
+
+	fmt.Println(load())
+	fmt.Println(load())
+
+	// Output:
+	// loading
+	// config
+	// config
+}
@@ -0,0 +1,102 @@
+package function
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestOnceValue(t *testing.T) {
+	assert := internal.NewAssert(t, "TestOnceValue")
+
+	var calls int32
+	get := OnceValue(func() int {
+		atomic.AddInt32(&calls, 1)
+		return 42
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(42, get())
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestOnceValue_Panic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestOnceValue_Panic")
+
+	calls := 0
+	get := OnceValue(func() int {
+		calls++
+		panic("boom")
+	})
+
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() {
+				assert.Equal("boom", recover())
+			}()
+			get()
+		}()
+	}
+	assert.Equal(1, calls)
+}
+
+func TestOnceValues(t *testing.T) {
+	assert := internal.NewAssert(t, "TestOnceValues")
+
+	calls := 0
+	get := OnceValues(func() (string, error) {
+		calls++
+		return "config", nil
+	})
+
+	v, err := get()
+	assert.Equal("config", v)
+	assert.IsNil(err)
+
+	get()
+	assert.Equal(1, calls)
+}
+
+func TestMemoize(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMemoize")
+
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	assert.Equal(4, square(2))
+	assert.Equal(4, square(2))
+	assert.Equal(9, square(3))
+	assert.Equal(2, calls)
+}
+
+func TestMemoizeWithTTL(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMemoizeWithTTL")
+
+	calls := 0
+	length := MemoizeWithTTL(func(s string) int {
+		calls++
+		return len(s)
+	}, 20*time.Millisecond)
+
+	assert.Equal(3, length("abc"))
+	assert.Equal(3, length("abc"))
+	assert.Equal(1, calls)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(3, length("abc"))
+	assert.Equal(2, calls)
+}
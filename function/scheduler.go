@@ -0,0 +1,393 @@
+package function
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of Scheduler, it can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) ClockTimer
+}
+
+// ClockTimer is a timer created by Clock.
+type ClockTimer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) ClockTimer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// OverlapPolicy decides what Scheduler does when a job is due while its previous run is still running.
+type OverlapPolicy int
+
+const (
+	// OverlapAllow starts the new run concurrently with the previous one.
+	OverlapAllow OverlapPolicy = iota
+	// OverlapSkip skips the new run.
+	OverlapSkip
+	// OverlapQueue starts the new run after the previous one finishes.
+	OverlapQueue
+)
+
+// JobID identifies a job added to Scheduler.
+type JobID int
+
+// JobInfo describes a job of Scheduler.
+type JobInfo struct {
+	ID   JobID
+	Name string
+	Spec string
+	Next time.Time
+	Prev time.Time
+}
+
+// SchedulerOption is for adding scheduler config.
+type SchedulerOption func(*Scheduler)
+
+// SchedulerClock set the clock of the scheduler, default is the system clock.
+func SchedulerClock(clock Clock) SchedulerOption {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// SchedulerLocation set the default time zone of cron expressions, default is time.Local.
+func SchedulerLocation(location *time.Location) SchedulerOption {
+	return func(s *Scheduler) {
+		s.location = location
+	}
+}
+
+// SchedulerPanicHandler set the function called with the recovered value and the stack when a job panics,
+// default logs the panic with the standard logger.
+func SchedulerPanicHandler(handler func(job JobInfo, value any, stack []byte)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.panicHandler = handler
+	}
+}
+
+// JobOption is for adding job config.
+type JobOption func(*schedulerJob)
+
+// JobName set the name of the job.
+func JobName(name string) JobOption {
+	return func(j *schedulerJob) {
+		j.name = name
+	}
+}
+
+// JobJitter delays every run of the job by a random duration in [0, jitter).
+func JobJitter(jitter time.Duration) JobOption {
+	return func(j *schedulerJob) {
+		j.jitter = jitter
+	}
+}
+
+// JobOverlap set the overlap policy of the job, default is OverlapAllow.
+func JobOverlap(policy OverlapPolicy) JobOption {
+	return func(j *schedulerJob) {
+		j.overlap = policy
+	}
+}
+
+type schedulerJob struct {
+	id       JobID
+	name     string
+	spec     string
+	schedule CronSchedule
+	fn       func(ctx context.Context)
+	jitter   time.Duration
+	overlap  OverlapPolicy
+
+	scheduled time.Time // next run time without jitter, the base of the run after it
+	next      time.Time
+	prev      time.Time
+	running   int
+	queued    int
+}
+
+// Scheduler runs jobs on cron schedules. A panic in a job is recovered and reported to the panic handler,
+// so it does not stop the scheduler.
+type Scheduler struct {
+	clock        Clock
+	location     *time.Location
+	panicHandler func(job JobInfo, value any, stack []byte)
+
+	mu      sync.Mutex
+	jobs    map[JobID]*schedulerJob
+	nextID  JobID
+	wake    chan struct{}
+	running sync.WaitGroup
+	random  *rand.Rand
+}
+
+// NewScheduler creates a Scheduler, call Run to start it.
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		clock:    realClock{},
+		location: time.Local,
+		jobs:     make(map[JobID]*schedulerJob),
+		wake:     make(chan struct{}, 1),
+		random:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		panicHandler: func(job JobInfo, value any, stack []byte) {
+			log.Printf("scheduler: job %d %q panicked: %v\n%s", job.ID, job.Name, value, stack)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// AddJob adds a job which runs on the cron expression spec, see ParseCron for the syntax.
+func (s *Scheduler) AddJob(spec string, job func(ctx context.Context), opts ...JobOption) (JobID, error) {
+	schedule, err := parseCron(spec, s.location)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.add(spec, schedule, job, opts), nil
+}
+
+// AddSchedule adds a job which runs on schedule.
+func (s *Scheduler) AddSchedule(schedule CronSchedule, job func(ctx context.Context), opts ...JobOption) JobID {
+	return s.add("", schedule, job, opts)
+}
+
+// Remove removes the job, a running run of the job is not interrupted, but the runs queued by OverlapQueue are dropped.
+// It returns false if there is no such job.
+func (s *Scheduler) Remove(id JobID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return false
+	}
+	job.queued = 0
+	delete(s.jobs, id)
+	s.notify()
+
+	return true
+}
+
+// Jobs returns all jobs ordered by their next run time.
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]JobInfo, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		result = append(result, job.info())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Next.Equal(result[j].Next) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].Next.Before(result[j].Next)
+	})
+
+	return result
+}
+
+// Run runs the due jobs until ctx is done, then waits for the running jobs to finish.
+// ctx is passed to the jobs, so they can stop early when the scheduler shuts down.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.running.Wait()
+
+	// the jobs added before Run are picked up by the first loop
+	select {
+	case <-s.wake:
+	default:
+	}
+
+	for {
+		now := s.clock.Now()
+
+		s.mu.Lock()
+		var earliest time.Time
+		for _, job := range s.jobs {
+			if job.next.IsZero() {
+				continue
+			}
+			if earliest.IsZero() || job.next.Before(earliest) {
+				earliest = job.next
+			}
+		}
+		s.mu.Unlock()
+
+		var timer ClockTimer
+		var fire <-chan time.Time
+		if !earliest.IsZero() {
+			timer = s.clock.NewTimer(earliest.Sub(now))
+			fire = timer.C()
+		}
+
+		select {
+		case <-fire:
+			s.runDue(ctx, s.clock.Now())
+		case <-s.wake:
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (s *Scheduler) add(spec string, schedule CronSchedule, fn func(ctx context.Context), opts []JobOption) JobID {
+	if fn == nil {
+		panic("Scheduler: job should not be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	job := &schedulerJob{id: s.nextID, spec: spec, schedule: schedule, fn: fn}
+	for _, opt := range opts {
+		opt(job)
+	}
+	job.scheduled = job.schedule.Next(s.clock.Now())
+	job.next = s.jitter(job, job.scheduled)
+
+	s.jobs[job.id] = job
+	s.notify()
+
+	return job.id
+}
+
+// jitter returns the run time of job scheduled at t with jitter, it should be called with s.mu held.
+func (s *Scheduler) jitter(job *schedulerJob, t time.Time) time.Time {
+	if t.IsZero() || job.jitter <= 0 {
+		return t
+	}
+
+	return t.Add(time.Duration(s.random.Int63n(int64(job.jitter))))
+}
+
+// reschedule moves job to its next run after now, it should be called with s.mu held.
+// The next run is computed from the scheduled time rather than now, so jitter and timer latency do not pile up.
+func (s *Scheduler) reschedule(job *schedulerJob, now time.Time) {
+	scheduled := job.schedule.Next(job.scheduled)
+	// runs missed while the scheduler was late are skipped
+	if !scheduled.IsZero() && !scheduled.After(now) {
+		scheduled = job.schedule.Next(now)
+	}
+
+	job.prev = job.next
+	job.scheduled = scheduled
+	job.next = s.jitter(job, scheduled)
+}
+
+// notify wakes up Run to recompute the next run time, it should be called with s.mu held.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+
+		s.reschedule(job, now)
+
+		if job.running > 0 {
+			switch job.overlap {
+			case OverlapSkip:
+				continue
+			case OverlapQueue:
+				job.queued++
+				continue
+			}
+		}
+
+		job.running++
+		s.running.Add(1)
+		go s.execute(ctx, job)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job *schedulerJob) {
+	defer s.running.Done()
+
+	for {
+		s.runJob(ctx, job)
+
+		s.mu.Lock()
+		if job.queued > 0 && ctx.Err() == nil {
+			job.queued--
+			s.mu.Unlock()
+			continue
+		}
+		job.running--
+		s.mu.Unlock()
+
+		return
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job *schedulerJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := debug.Stack()
+
+			s.mu.Lock()
+			info := job.info()
+			s.mu.Unlock()
+
+			if s.panicHandler != nil {
+				s.panicHandler(info, r, stack)
+			}
+		}
+	}()
+
+	job.fn(ctx)
+}
+
+// info should be called with s.mu held.
+func (job *schedulerJob) info() JobInfo {
+	return JobInfo{ID: job.id, Name: job.name, Spec: job.spec, Next: job.next, Prev: job.prev}
+}
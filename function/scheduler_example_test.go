@@ -0,0 +1,50 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExampleParseCron() {
+	schedule, err := ParseCron("CRON_TZ=UTC 30 9 * * MON-FRI")
+	if err != nil {
+		return
+	}
+
+	// 2024-01-06 is a saturday
+	next := schedule.Next(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC))
+
+	fmt.Println(next)
+
+	// Output:
+	// 2024-01-08 09:30:00 +0000 UTC
+}
+
+func ExampleScheduler() {
+	scheduler := NewScheduler()
+
+	count := make(chan int, 3)
+	n := 0
+	scheduler.AddJob("@every 10ms", func(ctx context.Context) {
+		n++
+		count <- n
+	}, JobOverlap(OverlapSkip))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	fmt.Println(<-count)
+	fmt.Println(<-count)
+
+	cancel()
+	<-done
+
+	// Output:
+	// 1
+	// 2
+}
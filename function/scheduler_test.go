@@ -0,0 +1,363 @@
+package function
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	created int
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) ClockTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.created++
+	if d <= 0 {
+		t.ch <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+
+	return t
+}
+
+// Advance moves the clock forward and fires the due timers.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			active = append(active, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = active
+}
+
+// waitTimers blocks until n timers have been created.
+func (c *fakeClock) waitTimers(t *testing.T, n int) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		created := c.created
+		c.mu.Unlock()
+		if created >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timeout waiting for %d timers", n)
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func newTestScheduler() (*Scheduler, *fakeClock) {
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewScheduler(SchedulerClock(clock), SchedulerLocation(time.UTC)), clock
+}
+
+func TestScheduler_Run(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_Run")
+
+	scheduler, clock := newTestScheduler()
+
+	runs := make(chan time.Time, 10)
+	_, err := scheduler.AddJob("*/10 * * * * *", func(ctx context.Context) {
+		runs <- clock.Now()
+	})
+	assert.IsNil(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	clock.waitTimers(t, 1)
+	clock.Advance(10 * time.Second)
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC), <-runs)
+
+	clock.waitTimers(t, 2)
+	clock.Advance(5 * time.Second)
+	clock.Advance(5 * time.Second)
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 20, 0, time.UTC), <-runs)
+
+	cancel()
+	<-done
+}
+
+func TestScheduler_JobsAndRemove(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_JobsAndRemove")
+
+	scheduler, _ := newTestScheduler()
+
+	id1, _ := scheduler.AddJob("@hourly", func(ctx context.Context) {}, JobName("hourly"))
+	id2, _ := scheduler.AddJob("0 * * * * *", func(ctx context.Context) {}, JobName("minutely"))
+	_, err := scheduler.AddJob("bad", func(ctx context.Context) {})
+	assert.IsNotNil(err)
+
+	jobs := scheduler.Jobs()
+	assert.Equal(2, len(jobs))
+	assert.Equal(JobInfo{ID: id2, Name: "minutely", Spec: "0 * * * * *", Next: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)}, jobs[0])
+	assert.Equal(id1, jobs[1].ID)
+	assert.Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), jobs[1].Next)
+
+	assert.Equal(true, scheduler.Remove(id2))
+	assert.Equal(false, scheduler.Remove(id2))
+	assert.Equal(1, len(scheduler.Jobs()))
+}
+
+func TestScheduler_Jitter(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_Jitter")
+
+	scheduler, _ := newTestScheduler()
+
+	for i := 0; i < 20; i++ {
+		scheduler.AddJob("@hourly", func(ctx context.Context) {}, JobJitter(time.Minute))
+	}
+
+	base := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	for _, job := range scheduler.Jobs() {
+		assert.Equal(false, job.Next.Before(base))
+		assert.Equal(true, job.Next.Before(base.Add(time.Minute)))
+	}
+}
+
+func TestScheduler_Overlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverlapPolicy
+		expected int32
+	}{
+		{"Allow", OverlapAllow, 3},
+		{"Skip", OverlapSkip, 1},
+		{"Queue", OverlapQueue, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := internal.NewAssert(t, "TestScheduler_Overlap_"+tt.name)
+
+			scheduler, clock := newTestScheduler()
+
+			var runs int32
+			release := make(chan struct{})
+			scheduler.AddJob("* * * * * *", func(ctx context.Context) {
+				atomic.AddInt32(&runs, 1)
+				<-release
+			}, JobOverlap(tt.policy))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				scheduler.Run(ctx)
+				close(done)
+			}()
+
+			for i := 1; i <= 3; i++ {
+				clock.waitTimers(t, i)
+				clock.Advance(time.Second)
+			}
+			clock.waitTimers(t, 4)
+
+			// the queued runs start after the release
+			close(release)
+
+			deadline := time.Now().Add(time.Second)
+			for atomic.LoadInt32(&runs) < tt.expected && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			cancel()
+			<-done
+
+			assert.Equal(tt.expected, atomic.LoadInt32(&runs))
+		})
+	}
+}
+
+func TestScheduler_Shutdown(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_Shutdown")
+
+	scheduler, clock := newTestScheduler()
+
+	started := make(chan struct{})
+	var stopped int32
+	scheduler.AddJob("* * * * * *", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		atomic.StoreInt32(&stopped, 1)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	clock.waitTimers(t, 1)
+	clock.Advance(time.Second)
+	<-started
+
+	cancel()
+	<-done
+
+	// Run waits for the running job
+	assert.Equal(int32(1), atomic.LoadInt32(&stopped))
+}
+
+func TestScheduler_Panic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_Panic")
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	panics := make(chan any, 2)
+	scheduler := NewScheduler(SchedulerClock(clock), SchedulerLocation(time.UTC),
+		SchedulerPanicHandler(func(job JobInfo, value any, stack []byte) {
+			assert.Equal("panicky", job.Name)
+			assert.Equal(true, len(stack) > 0)
+			panics <- value
+		}))
+
+	runs := make(chan struct{}, 2)
+	scheduler.AddJob("* * * * * *", func(ctx context.Context) {
+		runs <- struct{}{}
+		panic("boom")
+	}, JobName("panicky"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Run(ctx)
+
+	clock.waitTimers(t, 1)
+	clock.Advance(time.Second)
+	<-runs
+
+	assert.Equal("boom", <-panics)
+
+	clock.waitTimers(t, 2)
+	clock.Advance(time.Second)
+	<-runs
+	assert.Equal("boom", <-panics)
+
+	assert.Equal(0, len(runs))
+}
+
+func TestScheduler_NoDrift(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_NoDrift")
+
+	scheduler, clock := newTestScheduler()
+
+	runs := make(chan struct{}, 10)
+	scheduler.AddJob("@every 10s", func(ctx context.Context) {
+		runs <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// the timer fires 3s late, the next run is still on the 10s grid
+	clock.waitTimers(t, 1)
+	clock.Advance(13 * time.Second)
+	<-runs
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 20, 0, time.UTC), scheduler.Jobs()[0].Next)
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC), scheduler.Jobs()[0].Prev)
+
+	// missed runs are skipped
+	clock.waitTimers(t, 2)
+	clock.Advance(25 * time.Second)
+	<-runs
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 48, 0, time.UTC), scheduler.Jobs()[0].Next)
+
+	cancel()
+	<-done
+}
+
+func TestScheduler_RemoveQueued(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScheduler_RemoveQueued")
+
+	scheduler, clock := newTestScheduler()
+
+	var runs int32
+	release := make(chan struct{})
+	id, _ := scheduler.AddJob("* * * * * *", func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+		<-release
+	}, JobOverlap(OverlapQueue))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	for i := 1; i <= 3; i++ {
+		clock.waitTimers(t, i)
+		clock.Advance(time.Second)
+	}
+	clock.waitTimers(t, 4)
+
+	// the queued runs are dropped with the job
+	assert.Equal(true, scheduler.Remove(id))
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Equal(int32(1), atomic.LoadInt32(&runs))
+}
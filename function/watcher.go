@@ -1,13 +1,42 @@
 package function
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
 
-// Watcher is used for record code excution time
+// Watcher is used for record code excution time, it is a stopwatch with laps and pause/resume,
+// and it aggregates the durations of named spans. It uses the monotonic clock and is safe for concurrent use.
 // Play: https://go.dev/play/p/l2yrOpCLd1I
 type Watcher struct {
-	startTime int64
-	stopTime  int64
-	excuting  bool
+	mu       sync.Mutex
+	start    time.Time     // when the current running period began
+	elapsed  time.Duration // elapsed time of the finished running periods
+	excuting bool
+	paused   bool
+	splits   []time.Duration
+	spans    map[string][]time.Duration
+}
+
+// SpanStats is the summary of the durations recorded for a named span.
+type SpanStats struct {
+	Name  string
+	Count int
+	Total time.Duration
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// String returns a one line report of the span stats.
+func (s SpanStats) String() string {
+	return fmt.Sprintf("%s: count=%d total=%v min=%v max=%v mean=%v p50=%v p95=%v p99=%v",
+		s.Name, s.Count, s.Total, s.Min, s.Max, s.Mean, s.P50, s.P95, s.P99)
 }
 
 // Start the watch timer.
@@ -15,29 +44,221 @@ func NewWatcher() *Watcher {
 	return &Watcher{}
 }
 
-// Start the watch timer.
+// Start the watch timer, the elapsed time and laps of the previous run are discarded.
 func (w *Watcher) Start() {
-	w.startTime = time.Now().UnixNano()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.start = time.Now()
+	w.elapsed = 0
+	w.splits = nil
 	w.excuting = true
+	w.paused = false
 }
 
 // Stop the watch timer.
 func (w *Watcher) Stop() {
-	w.stopTime = time.Now().UnixNano()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.elapsed = w.elapsedLocked()
 	w.excuting = false
+	w.paused = false
 }
 
-// GetElapsedTime get excute elapsed time.
-func (w *Watcher) GetElapsedTime() time.Duration {
-	if w.excuting {
-		return time.Duration(time.Now().UnixNano() - w.startTime)
+// Pause the watch timer, the time until Resume is not counted.
+func (w *Watcher) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.excuting && !w.paused {
+		w.elapsed += time.Since(w.start)
+		w.paused = true
 	}
-	return time.Duration(w.stopTime - w.startTime)
 }
 
-// Reset the watch timer.
+// Resume the watch timer paused by Pause.
+func (w *Watcher) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.excuting && w.paused {
+		w.start = time.Now()
+		w.paused = false
+	}
+}
+
+// IsRunning reports whether the watch timer is started and not paused.
+func (w *Watcher) IsRunning() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.excuting && !w.paused
+}
+
+// Lap records a lap and returns its duration, the elapsed time since the previous lap or the start.
+func (w *Watcher) Lap() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	split := w.elapsedLocked()
+	lap := split
+	if n := len(w.splits); n > 0 {
+		lap -= w.splits[n-1]
+	}
+	w.splits = append(w.splits, split)
+
+	return lap
+}
+
+// Laps returns the durations of the recorded laps.
+func (w *Watcher) Laps() []time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	laps := make([]time.Duration, len(w.splits))
+	var prev time.Duration
+	for i, split := range w.splits {
+		laps[i] = split - prev
+		prev = split
+	}
+
+	return laps
+}
+
+// Splits returns the elapsed time since the start at every recorded lap.
+func (w *Watcher) Splits() []time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]time.Duration{}, w.splits...)
+}
+
+// GetElapsedTime get excute elapsed time, paused time is not counted.
+func (w *Watcher) GetElapsedTime() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.elapsedLocked()
+}
+
+// Reset the watch timer, its laps and spans.
 func (w *Watcher) Reset() {
-	w.startTime = 0
-	w.stopTime = 0
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.start = time.Time{}
+	w.elapsed = 0
+	w.splits = nil
+	w.spans = nil
 	w.excuting = false
+	w.paused = false
+}
+
+// StartSpan starts timing the span name, calling the returned function records its duration.
+// Spans are independent of the watch timer and may overlap.
+func (w *Watcher) StartSpan(name string) func() time.Duration {
+	start := time.Now()
+	var once sync.Once
+	var d time.Duration
+
+	return func() time.Duration {
+		once.Do(func() {
+			d = time.Since(start)
+			w.Record(name, d)
+		})
+		return d
+	}
+}
+
+// Record adds the duration d to the span name.
+func (w *Watcher) Record(name string, d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.spans == nil {
+		w.spans = make(map[string][]time.Duration)
+	}
+	w.spans[name] = append(w.spans[name], d)
+}
+
+// Measure runs fn once, records its duration to the span name and returns it.
+func (w *Watcher) Measure(name string, fn func()) time.Duration {
+	stop := w.StartSpan(name)
+	fn()
+	return stop()
+}
+
+// MeasureN runs fn n times, records every run to the span name and returns the stats of the span.
+func (w *Watcher) MeasureN(name string, n int, fn func()) SpanStats {
+	for i := 0; i < n; i++ {
+		w.Measure(name, fn)
+	}
+	return w.Span(name)
+}
+
+// Span returns the stats of the span name, Count is 0 if nothing is recorded.
+func (w *Watcher) Span(name string) SpanStats {
+	w.mu.Lock()
+	durations := append([]time.Duration{}, w.spans[name]...)
+	w.mu.Unlock()
+
+	return newSpanStats(name, durations)
+}
+
+// Spans returns the stats of all spans sorted by name.
+func (w *Watcher) Spans() []SpanStats {
+	w.mu.Lock()
+	names := make([]string, 0, len(w.spans))
+	for name := range w.spans {
+		names = append(names, name)
+	}
+	w.mu.Unlock()
+
+	sort.Strings(names)
+
+	result := make([]SpanStats, len(names))
+	for i, name := range names {
+		result[i] = w.Span(name)
+	}
+
+	return result
+}
+
+// elapsedLocked should be called with w.mu held.
+func (w *Watcher) elapsedLocked() time.Duration {
+	if w.excuting && !w.paused {
+		return w.elapsed + time.Since(w.start)
+	}
+	return w.elapsed
+}
+
+func newSpanStats(name string, durations []time.Duration) SpanStats {
+	stats := SpanStats{Name: name, Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	for _, d := range durations {
+		stats.Total += d
+	}
+	stats.Min = durations[0]
+	stats.Max = durations[len(durations)-1]
+	stats.Mean = stats.Total / time.Duration(len(durations))
+	stats.P50 = percentile(durations, 50)
+	stats.P95 = percentile(durations, 95)
+	stats.P99 = percentile(durations, 99)
+
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted durations using the nearest rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
 }
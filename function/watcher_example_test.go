@@ -1,6 +1,9 @@
 package function
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 func ExampleWatcher() {
 	w := NewWatcher()
@@ -20,3 +23,34 @@ func ExampleWatcher() {
 	// Output:
 	// foo
 }
+
+func ExampleWatcher_Lap() {
+	w := NewWatcher()
+
+	w.Start()
+
+	w.Lap()
+	w.Lap()
+
+	w.Stop()
+
+	fmt.Println(len(w.Laps()))
+
+	// Output:
+	// 2
+}
+
+func ExampleWatcher_Span() {
+	w := NewWatcher()
+
+	for i := 1; i <= 4; i++ {
+		w.Record("query", time.Duration(i)*time.Millisecond)
+	}
+
+	stats := w.Span("query")
+
+	fmt.Println(stats.Count, stats.Min, stats.Max, stats.Mean, stats.P50)
+
+	// Output:
+	// 4 1ms 4ms 2.5ms 2ms
+}
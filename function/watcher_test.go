@@ -1,7 +1,9 @@
 package function
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/serialt/lancet/internal"
 )
@@ -25,8 +27,125 @@ func TestWatcher(t *testing.T) {
 
 	w.Reset()
 
-	assert.Equal(int64(0), w.startTime)
-	assert.Equal(int64(0), w.stopTime)
+	assert.Equal(time.Duration(0), w.GetElapsedTime())
+	assert.Equal(false, w.excuting)
+}
+
+func TestWatcherPauseResume(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWatcherPauseResume")
+
+	w := NewWatcher()
+	w.Start()
+	time.Sleep(20 * time.Millisecond)
+
+	w.Pause()
+	assert.Equal(false, w.IsRunning())
+	paused := w.GetElapsedTime()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(paused, w.GetElapsedTime())
+
+	w.Resume()
+	assert.Equal(true, w.IsRunning())
+	time.Sleep(20 * time.Millisecond)
+	w.Stop()
+
+	elapsed := w.GetElapsedTime()
+	assert.Equal(true, elapsed >= 40*time.Millisecond)
+	assert.Equal(elapsed, w.GetElapsedTime())
+}
+
+func TestWatcherLaps(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWatcherLaps")
+
+	w := NewWatcher()
+	w.Start()
+
+	time.Sleep(10 * time.Millisecond)
+	lap1 := w.Lap()
+	time.Sleep(20 * time.Millisecond)
+	lap2 := w.Lap()
+	w.Stop()
+
+	assert.Equal(true, lap1 >= 10*time.Millisecond)
+	assert.Equal(true, lap2 >= 20*time.Millisecond)
+	assert.Equal([]time.Duration{lap1, lap2}, w.Laps())
+	assert.Equal([]time.Duration{lap1, lap1 + lap2}, w.Splits())
+
+	w.Start()
+	assert.Equal(0, len(w.Laps()))
+}
+
+func TestWatcherSpans(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWatcherSpans")
+
+	w := NewWatcher()
+	for i := 1; i <= 100; i++ {
+		w.Record("query", time.Duration(i)*time.Millisecond)
+	}
+
+	stats := w.Span("query")
+	assert.Equal(100, stats.Count)
+	assert.Equal(time.Millisecond, stats.Min)
+	assert.Equal(100*time.Millisecond, stats.Max)
+	assert.Equal(5050*time.Millisecond, stats.Total)
+	assert.Equal(50500*time.Microsecond, stats.Mean)
+	assert.Equal(50*time.Millisecond, stats.P50)
+	assert.Equal(95*time.Millisecond, stats.P95)
+	assert.Equal(99*time.Millisecond, stats.P99)
+
+	assert.Equal(0, w.Span("missing").Count)
+
+	stop := w.StartSpan("render")
+	d := stop()
+	assert.Equal(d, stop())
+	assert.Equal(1, w.Span("render").Count)
+
+	spans := w.Spans()
+	assert.Equal(2, len(spans))
+	assert.Equal("query", spans[0].Name)
+	assert.Equal("render", spans[1].Name)
+
+	w.Reset()
+	assert.Equal(0, len(w.Spans()))
+}
+
+func TestWatcherMeasure(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWatcherMeasure")
+
+	w := NewWatcher()
+	d := w.Measure("sleep", func() { time.Sleep(5 * time.Millisecond) })
+	assert.Equal(true, d >= 5*time.Millisecond)
+
+	count := 0
+	stats := w.MeasureN("count", 10, func() { count++ })
+	assert.Equal(10, count)
+	assert.Equal(10, stats.Count)
+	assert.Equal(true, stats.Min <= stats.P50 && stats.P50 <= stats.Max)
+}
+
+func TestWatcherConcurrent(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWatcherConcurrent")
+
+	w := NewWatcher()
+	w.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				w.Measure("task", func() {})
+				w.Lap()
+				w.GetElapsedTime()
+			}
+		}()
+	}
+	wg.Wait()
+	w.Stop()
+
+	assert.Equal(1000, w.Span("task").Count)
+	assert.Equal(1000, len(w.Laps()))
 }
 
 func longRunningTask() []int64 {
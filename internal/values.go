@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ContainsValue checks if value is in values or not, values are compared with reflect.DeepEqual.
+// It is shared by the data structures, do not use it outside lancet lib.
+func ContainsValue[T any](values []T, value T) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatValues returns the string form of values, eg. [1 2 3]
+// It is shared by the data structures, do not use it outside lancet lib.
+func FormatValues[T any](values []T) string {
+	return fmt.Sprint(values)
+}
@@ -0,0 +1,125 @@
+// Package iterator provides a way to iterate over values stored in containers.
+// Operators like Map, Filter, Zip and Window are lazy, they pull items from the source iterator only when Next is called.
+// Terminal operations like Reduce, Count and GroupBy consume the whole iterator.
+// Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
+package iterator
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// ErrIterator is an iterator over a source which may fail, eg. a file, database rows or http pagination.
+// When Next returns ok==false, Err reports whether the iteration ends normally (nil) or because of an error.
+type ErrIterator[T any] interface {
+	Iterator[T]
+
+	// Err returns the error which stops the iteration, it returns nil if the source is exhausted normally.
+	Err() error
+}
+
+type errIterator[T any] struct {
+	funcIterator[T]
+	err error
+}
+
+func (iter *errIterator[T]) Err() error {
+	return iter.err
+}
+
+// FromFunc returns an ErrIterator which gets items by calling fetch until it returns an error.
+// fetch should return io.EOF when there is no more item, any other error is reported by Err.
+func FromFunc[T any](fetch func() (T, error)) ErrIterator[T] {
+	iter := &errIterator[T]{}
+	iter.fetch = func() (T, bool) {
+		item, err := fetch()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				iter.err = err
+			}
+			var zero T
+			return zero, false
+		}
+		return item, true
+	}
+
+	return iter
+}
+
+// FromScanner returns an ErrIterator over the tokens of scanner, Err returns the error of scanner.
+func FromScanner(scanner *bufio.Scanner) ErrIterator[string] {
+	return FromFunc(func() (string, error) {
+		if scanner.Scan() {
+			return scanner.Text(), nil
+		}
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	})
+}
+
+// FromLines returns an ErrIterator over the lines of reader, line endings are stripped.
+func FromLines(reader io.Reader) ErrIterator[string] {
+	return FromScanner(bufio.NewScanner(reader))
+}
+
+// FromCSV returns an ErrIterator over the csv records of reader.
+func FromCSV(reader io.Reader) ErrIterator[[]string] {
+	return FromFunc(csv.NewReader(reader).Read)
+}
+
+// MapContext is like Map, but the iteration stops when ctx is done, and Err returns the error of ctx.
+// ctx is checked between items, a Next call blocked in iter is not interrupted when ctx is done.
+// If iter is an ErrIterator, its error is reported by Err too.
+func MapContext[T any, U any](ctx context.Context, iter Iterator[T], iteratee func(item T) U) ErrIterator[U] {
+	return FromFunc(func() (U, error) {
+		item, err := nextContext(ctx, iter)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return iteratee(item), nil
+	})
+}
+
+// FilterContext is like Filter, but the iteration stops when ctx is done, and Err returns the error of ctx.
+// ctx is checked between items, including the ones rejected by predicateFunc, a Next call blocked in iter is not interrupted.
+// If iter is an ErrIterator, its error is reported by Err too.
+func FilterContext[T any](ctx context.Context, iter Iterator[T], predicateFunc func(item T) bool) ErrIterator[T] {
+	return FromFunc(func() (T, error) {
+		for {
+			item, err := nextContext(ctx, iter)
+			if err != nil {
+				return item, err
+			}
+			if predicateFunc(item) {
+				return item, nil
+			}
+		}
+	})
+}
+
+// nextContext returns the next item of iter, or the error of ctx if it is done,
+// or io.EOF if iter is exhausted without error.
+// ctx is checked only before calling iter.Next, waiting for Next in another goroutine could not give the item back once ctx is done.
+func nextContext[T any](ctx context.Context, iter Iterator[T]) (T, error) {
+	var zero T
+
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	item, ok := iter.Next()
+	if !ok {
+		if errIter, isErrIter := iter.(ErrIterator[T]); isErrIter && errIter.Err() != nil {
+			return zero, errIter.Err()
+		}
+		return zero, io.EOF
+	}
+
+	return item, nil
+}
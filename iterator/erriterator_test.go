@@ -0,0 +1,91 @@
+// Package iterator provides a way to iterate over values stored in containers.
+// Operators like Map, Filter, Zip and Window are lazy, they pull items from the source iterator only when Next is called.
+// Terminal operations like Reduce, Count and GroupBy consume the whole iterator.
+// Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
+package iterator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestFromFunc(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFromFunc")
+
+	pages := [][]int{{1, 2}, {3}}
+	errPage := errors.New("page not found")
+
+	page := 0
+	iter := FromFunc(func() ([]int, error) {
+		if page == len(pages) {
+			return nil, errPage
+		}
+		page++
+		return pages[page-1], nil
+	})
+
+	assert.Equal([][]int{{1, 2}, {3}}, ToSlice[[]int](iter))
+	assert.Equal(errPage, iter.Err())
+
+	iter = FromFunc(func() ([]int, error) { return nil, io.EOF })
+	assert.Equal(false, iter.HasNext())
+	assert.IsNil(iter.Err())
+}
+
+func TestFromLines(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFromLines")
+
+	iter := FromLines(strings.NewReader("a\nb\r\nc"))
+	assert.Equal([]string{"a", "b", "c"}, ToSlice[string](iter))
+	assert.IsNil(iter.Err())
+}
+
+func TestFromCSV(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFromCSV")
+
+	iter := FromCSV(strings.NewReader("a,b\nc,d\n"))
+	assert.Equal([][]string{{"a", "b"}, {"c", "d"}}, ToSlice[[]string](iter))
+	assert.IsNil(iter.Err())
+
+	iter = FromCSV(strings.NewReader("a,b\nc\n"))
+	assert.Equal([][]string{{"a", "b"}}, ToSlice[[]string](iter))
+	assert.IsNotNil(iter.Err())
+}
+
+func TestMapContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMapContext")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	iter := MapContext(ctx, FromRange(0, 100, 1), func(n int) int {
+		if n == 2 {
+			cancel()
+		}
+		return n * 10
+	})
+
+	assert.Equal([]int{0, 10, 20}, ToSlice[int](iter))
+	assert.Equal(context.Canceled, iter.Err())
+}
+
+func TestFilterContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFilterContext")
+
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	iter := FilterContext(context.Background(), FromRange(0, 5, 1), isEven)
+	assert.Equal([]int{0, 2, 4}, ToSlice[int](iter))
+	assert.IsNil(iter.Err())
+
+	errRead := errors.New("read error")
+	source := FromFunc(func() (int, error) { return 0, errRead })
+
+	iter = FilterContext[int](context.Background(), source, isEven)
+	assert.Equal([]int{}, ToSlice[int](iter))
+	assert.Equal(errRead, iter.Err())
+}
@@ -2,15 +2,13 @@
 // Use of this source code is governed by MIT license
 
 // Package iterator provides a way to iterate over values stored in containers.
-// note:
-// 1. Full feature iterator is complicated, this package is just a experiment to explore how iterators could work in Go.
-// 2. The functionality of this package is very simple and limited, may not meet the actual dev needs.
-// 3. It is currently under development, unstable, and will not be completed for some time in the future.
-// So, based on above factors, you may not use it in production. but, anyone is welcome to improve it.
+// Operators like Map, Filter, Zip and Window are lazy, they pull items from the source iterator only when Next is called.
+// Terminal operations like Reduce, Count and GroupBy consume the whole iterator.
 // Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
 package iterator
 
 import (
+	"container/list"
 	"context"
 
 	"golang.org/x/exp/constraints"
@@ -82,7 +80,7 @@ type PrevIterator[T any] interface {
 
 // FromSlice returns an iterator over a slice of data.
 func FromSlice[T any](slice []T) Iterator[T] {
-	return &sliceIterator[T]{slice: slice, index: -1}
+	return NewSliceIterator(slice)
 }
 
 func ToSlice[T any](iter Iterator[T]) []T {
@@ -93,30 +91,52 @@ func ToSlice[T any](iter Iterator[T]) []T {
 	return result
 }
 
-type sliceIterator[T any] struct {
-	slice []T
-	index int
+var (
+	_ PrevIterator[any]   = (*SliceIterator[any])(nil)
+	_ SetIterator[any]    = (*SliceIterator[any])(nil)
+	_ DeleteIterator[any] = (*SliceIterator[any])(nil)
+)
+
+// SliceIterator is an iterator over a slice, it implements PrevIterator, SetIterator and DeleteIterator.
+type SliceIterator[T any] struct {
+	slice   []T
+	index   int
+	deleted bool
+}
+
+// NewSliceIterator returns a SliceIterator pointer over slice.
+// Set writes to slice directly, Delete shifts the rest items of slice forward, use Slice to get the result.
+func NewSliceIterator[T any](slice []T) *SliceIterator[T] {
+	return &SliceIterator[T]{slice: slice, index: -1}
 }
 
-func (iter *sliceIterator[T]) HasNext() bool {
+// Slice returns the slice after all Set and Delete operations.
+func (iter *SliceIterator[T]) Slice() []T {
+	return iter.slice
+}
+
+func (iter *SliceIterator[T]) HasNext() bool {
 	return iter.index < len(iter.slice)-1
 }
 
-func (iter *sliceIterator[T]) Next() (T, bool) {
+func (iter *SliceIterator[T]) Next() (T, bool) {
 	iter.index++
+	iter.deleted = false
 
 	ok := iter.index >= 0 && iter.index < len(iter.slice)
 
 	var item T
 	if ok {
 		item = iter.slice[iter.index]
+	} else {
+		iter.index = len(iter.slice)
 	}
 
 	return item, ok
 }
 
 // Prev implements PrevIterator.
-func (iter *sliceIterator[T]) Prev() {
+func (iter *SliceIterator[T]) Prev() {
 	if iter.index == -1 {
 		panic("Next function should be called Prev")
 	}
@@ -128,14 +148,165 @@ func (iter *sliceIterator[T]) Prev() {
 }
 
 // Set implements SetIterator.
-func (iter *sliceIterator[T]) Set(value T) {
+func (iter *SliceIterator[T]) Set(value T) {
+	iter.checkCurrent("Set")
+	iter.slice[iter.index] = value
+}
+
+// Delete implements DeleteIterator.
+func (iter *SliceIterator[T]) Delete() {
+	iter.checkCurrent("Delete")
+
+	copy(iter.slice[iter.index:], iter.slice[iter.index+1:])
+
+	var zero T
+	iter.slice[len(iter.slice)-1] = zero
+	iter.slice = iter.slice[:len(iter.slice)-1]
+
+	iter.index--
+	iter.deleted = true
+}
+
+func (iter *SliceIterator[T]) checkCurrent(method string) {
 	if iter.index == -1 {
-		panic("Next function should be called Set")
+		panic("Next function should be called " + method)
 	}
-	if iter.index >= len(iter.slice) || len(iter.slice) == 0 {
+	if iter.deleted || iter.index >= len(iter.slice) || len(iter.slice) == 0 {
 		panic("No element in current iterator")
 	}
-	iter.slice[iter.index] = value
+}
+
+var (
+	_ SetIterator[Pair[string, any]]    = (*MapIterator[string, any])(nil)
+	_ DeleteIterator[Pair[string, any]] = (*MapIterator[string, any])(nil)
+)
+
+// MapIterator is an iterator over the entries of a map, it implements SetIterator and DeleteIterator.
+// The keys are collected when the iterator is created, keys deleted from the map later are skipped, keys added later are not visited.
+type MapIterator[K comparable, V any] struct {
+	m       map[K]V
+	keys    []K
+	index   int
+	current *K
+}
+
+// NewMapIterator returns a MapIterator pointer over the entries of m, the order of entries is not specified.
+func NewMapIterator[K comparable, V any](m map[K]V) *MapIterator[K, V] {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return &MapIterator[K, V]{m: m, keys: keys}
+}
+
+func (iter *MapIterator[K, V]) HasNext() bool {
+	for i := iter.index; i < len(iter.keys); i++ {
+		if _, ok := iter.m[iter.keys[i]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (iter *MapIterator[K, V]) Next() (Pair[K, V], bool) {
+	iter.current = nil
+
+	for iter.index < len(iter.keys) {
+		key := iter.keys[iter.index]
+		iter.index++
+
+		if value, ok := iter.m[key]; ok {
+			iter.current = &key
+			return Pair[K, V]{First: key, Second: value}, true
+		}
+	}
+
+	return Pair[K, V]{}, false
+}
+
+// Set implements SetIterator, it stores entry.Second under entry.First,
+// and deletes the current entry if entry.First is a different key.
+func (iter *MapIterator[K, V]) Set(entry Pair[K, V]) {
+	key := iter.checkCurrent("Set")
+	if entry.First != key {
+		delete(iter.m, key)
+		iter.current = &entry.First
+	}
+	iter.m[entry.First] = entry.Second
+}
+
+// Delete implements DeleteIterator.
+func (iter *MapIterator[K, V]) Delete() {
+	delete(iter.m, iter.checkCurrent("Delete"))
+	iter.current = nil
+}
+
+func (iter *MapIterator[K, V]) checkCurrent(method string) K {
+	if iter.current == nil {
+		panic("Next function should be called " + method)
+	}
+	return *iter.current
+}
+
+var (
+	_ SetIterator[any]    = (*ListIterator[any])(nil)
+	_ DeleteIterator[any] = (*ListIterator[any])(nil)
+)
+
+// ListIterator is an iterator over a container/list.List, it implements SetIterator and DeleteIterator.
+type ListIterator[T any] struct {
+	list    *list.List
+	current *list.Element
+	next    *list.Element
+	started bool
+}
+
+// NewListIterator returns a ListIterator pointer over l, values of l should be of type T.
+func NewListIterator[T any](l *list.List) *ListIterator[T] {
+	return &ListIterator[T]{list: l}
+}
+
+func (iter *ListIterator[T]) HasNext() bool {
+	if !iter.started {
+		return iter.list.Front() != nil
+	}
+	return iter.next != nil
+}
+
+func (iter *ListIterator[T]) Next() (T, bool) {
+	if !iter.started {
+		iter.started = true
+		iter.next = iter.list.Front()
+	}
+
+	iter.current = iter.next
+	if iter.current == nil {
+		var zero T
+		return zero, false
+	}
+	iter.next = iter.current.Next()
+
+	return iter.current.Value.(T), true
+}
+
+// Set implements SetIterator.
+func (iter *ListIterator[T]) Set(value T) {
+	iter.checkCurrent("Set")
+	iter.current.Value = value
+}
+
+// Delete implements DeleteIterator.
+func (iter *ListIterator[T]) Delete() {
+	iter.checkCurrent("Delete")
+	iter.list.Remove(iter.current)
+	iter.current = nil
+}
+
+func (iter *ListIterator[T]) checkCurrent(method string) {
+	if iter.current == nil {
+		panic("Next function should be called " + method)
+	}
 }
 
 // FromRange creates a iterator which returns the numeric range between start inclusive and end
@@ -168,31 +339,75 @@ func (iter *rangeIterator[T]) Next() (T, bool) {
 	return num, true
 }
 
-// FromRange creates a iterator which returns the numeric range between start inclusive and end
-// exclusive by the step size. start should be less than end, step shoud be positive.
-func FromChannel[T any](channel <-chan T) Iterator[T] {
+// FromChannel returns an iterator over the items received from channel.
+// Stop only ends the iteration, use FromChannelWithDone if the goroutine sending items should be released by Stop.
+func FromChannel[T any](channel <-chan T) StopIterator[T] {
 	return &channelIterator[T]{channel: channel}
 }
 
+// FromChannelWithDone returns an iterator over the items received from channel, Stop closes done.
+// The goroutine sending items should select on done, so that it exits once the consumer has stopped.
+func FromChannelWithDone[T any](channel <-chan T, done chan struct{}) StopIterator[T] {
+	return &channelIterator[T]{channel: channel, cancel: done}
+}
+
 type channelIterator[T any] struct {
 	channel <-chan T
+	cancel  chan struct{}
+	done    bool
+	stopped bool
 }
 
 func (iter *channelIterator[T]) Next() (T, bool) {
+	if iter.done {
+		var zero T
+		return zero, false
+	}
+
 	item, ok := <-iter.channel
+	if !ok {
+		iter.done = true
+	}
 	return item, ok
 }
 
+// HasNext reports false only after the channel is found closed or the iterator is stopped,
+// because it can't tell whether there is a next item without receiving it.
 func (iter *channelIterator[T]) HasNext() bool {
-	return len(iter.channel) == 0
+	return !iter.done
+}
+
+// Stop implements StopIterator, it closes the done channel given to FromChannelWithDone.
+func (iter *channelIterator[T]) Stop() {
+	iter.done = true
+
+	if iter.cancel != nil && !iter.stopped {
+		iter.stopped = true
+		close(iter.cancel)
+	}
 }
 
 // ToChannel create a new goroutine to pull items from the channel iterator to the returned channel.
+// The goroutine exits when iter is exhausted or ctx is done, then it stops iter if iter is a StopIterator and closes the channel.
 func ToChannel[T any](ctx context.Context, iter Iterator[T], buffer int) <-chan T {
+	channel, _ := ToStoppableChannel(ctx, iter, buffer)
+	return channel
+}
+
+// ToStoppableChannel is like ToChannel, and the goroutine also exits when the returned stop function is called.
+// stop may be called multiple times.
+func ToStoppableChannel[T any](ctx context.Context, iter Iterator[T], buffer int) (channel <-chan T, stop func()) {
 	result := make(chan T, buffer)
+	ctx, cancel := context.WithCancel(ctx)
 
 	go func() {
-		defer close(result)
+		defer func() {
+			cancel()
+			if stopper, ok := iter.(StopIterator[T]); ok {
+				stopper.Stop()
+			}
+			close(result)
+		}()
 
 		for item, ok := iter.Next(); ok; item, ok = iter.Next() {
 			select {
@@ -203,5 +418,93 @@ func ToChannel[T any](ctx context.Context, iter Iterator[T], buffer int) <-chan
 		}
 	}()
 
-	return result
+	return result, cancel
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Wrappers that add look-ahead to an Iterator.                                                   //
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PeekableIterator wraps an iterator so that the next items can be looked at without consuming them.
+type PeekableIterator[T any] struct {
+	iter   Iterator[T]
+	buffer []T
+}
+
+// NewPeekable returns a PeekableIterator pointer wrapping iter.
+func NewPeekable[T any](iter Iterator[T]) *PeekableIterator[T] {
+	return &PeekableIterator[T]{iter: iter}
+}
+
+func (iter *PeekableIterator[T]) HasNext() bool {
+	return len(iter.buffer) > 0 || iter.iter.HasNext()
+}
+
+func (iter *PeekableIterator[T]) Next() (T, bool) {
+	if len(iter.buffer) > 0 {
+		item := iter.buffer[0]
+		iter.buffer = iter.buffer[1:]
+		return item, true
+	}
+	return iter.iter.Next()
+}
+
+// Peek returns the next item without consuming it, and false if there is no next item.
+func (iter *PeekableIterator[T]) Peek() (T, bool) {
+	return iter.PeekAt(0)
+}
+
+// PeekAt returns the item n positions after the next item without consuming anything, PeekAt(0) is the same as Peek.
+func (iter *PeekableIterator[T]) PeekAt(n int) (T, bool) {
+	for len(iter.buffer) <= n {
+		item, ok := iter.iter.Next()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		iter.buffer = append(iter.buffer, item)
+	}
+	return iter.buffer[n], true
+}
+
+// ResettableIterator wraps an iterator so that it can go back to a marked position and iterate again.
+// Items after the mark are buffered, call Mark when earlier items are no longer needed.
+type ResettableIterator[T any] struct {
+	iter   Iterator[T]
+	buffer []T
+	pos    int
+}
+
+// NewResettable returns a ResettableIterator pointer wrapping iter, the initial mark is the beginning of iter.
+func NewResettable[T any](iter Iterator[T]) *ResettableIterator[T] {
+	return &ResettableIterator[T]{iter: iter}
+}
+
+func (iter *ResettableIterator[T]) HasNext() bool {
+	return iter.pos < len(iter.buffer) || iter.iter.HasNext()
+}
+
+func (iter *ResettableIterator[T]) Next() (T, bool) {
+	if iter.pos < len(iter.buffer) {
+		iter.pos++
+		return iter.buffer[iter.pos-1], true
+	}
+
+	item, ok := iter.iter.Next()
+	if ok {
+		iter.buffer = append(iter.buffer, item)
+		iter.pos++
+	}
+	return item, ok
+}
+
+// Mark sets the current position as the position Reset goes back to, items before it are released.
+func (iter *ResettableIterator[T]) Mark() {
+	iter.buffer = append([]T{}, iter.buffer[iter.pos:]...)
+	iter.pos = 0
+}
+
+// Reset goes back to the last marked position, so the items after it are returned again.
+func (iter *ResettableIterator[T]) Reset() {
+	iter.pos = 0
 }
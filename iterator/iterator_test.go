@@ -5,6 +5,7 @@
 package iterator
 
 import (
+	"container/list"
 	"context"
 	"testing"
 
@@ -101,3 +102,156 @@ func TestChannelIterator(t *testing.T) {
 	_, ok = iter.Next()
 	assert.Equal(false, ok)
 }
+
+func TestSliceIterator_SetAndDelete(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSliceIterator_SetAndDelete")
+
+	iter := NewSliceIterator([]int{1, 2, 3, 4})
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if item%2 == 0 {
+			iter.Delete()
+		} else {
+			iter.Set(item * 10)
+		}
+	}
+
+	assert.Equal([]int{10, 30}, iter.Slice())
+}
+
+func TestMapIterator_SetAndDelete(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMapIterator_SetAndDelete")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	iter := NewMapIterator(m)
+	for entry, ok := iter.Next(); ok; entry, ok = iter.Next() {
+		switch entry.First {
+		case "a":
+			iter.Delete()
+		case "b":
+			iter.Set(Pair[string, int]{First: "b", Second: 20})
+		case "c":
+			iter.Set(Pair[string, int]{First: "d", Second: 4})
+		}
+	}
+
+	assert.Equal(map[string]int{"b": 20, "d": 4}, m)
+	assert.Equal(false, iter.HasNext())
+}
+
+func TestListIterator_SetAndDelete(t *testing.T) {
+	assert := internal.NewAssert(t, "TestListIterator_SetAndDelete")
+
+	l := list.New()
+	for i := 1; i <= 4; i++ {
+		l.PushBack(i)
+	}
+
+	iter := NewListIterator[int](l)
+	assert.Equal(true, iter.HasNext())
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if item%2 == 0 {
+			iter.Delete()
+		} else {
+			iter.Set(item * 10)
+		}
+	}
+
+	result := []int{}
+	for e := l.Front(); e != nil; e = e.Next() {
+		result = append(result, e.Value.(int))
+	}
+	assert.Equal([]int{10, 30}, result)
+}
+
+func TestChannelIterator_Stop(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChannelIterator_Stop")
+
+	source := FromRange(0, 1000000, 1)
+	channel, stop := ToStoppableChannel(context.Background(), source, 0)
+
+	iter := FromChannel(channel)
+	item, ok := iter.Next()
+	assert.Equal(0, item)
+	assert.Equal(true, ok)
+
+	iter.Stop()
+	stop()
+	stop()
+	assert.Equal(false, iter.HasNext())
+
+	_, ok = iter.Next()
+	assert.Equal(false, ok)
+
+	// the producer goroutine closes channel after it is released
+	for range channel {
+	}
+}
+
+func TestChannelIterator_StopReleasesProducer(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChannelIterator_StopReleasesProducer")
+
+	channel := make(chan int)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		for i := 0; ; i++ {
+			select {
+			case channel <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	iter := FromChannelWithDone(channel, done)
+	item, ok := iter.Next()
+	assert.Equal(0, item)
+	assert.Equal(true, ok)
+
+	iter.Stop()
+	iter.Stop()
+	<-exited
+
+	_, ok = iter.Next()
+	assert.Equal(false, ok)
+	assert.Equal(false, iter.HasNext())
+}
+
+func TestPeekableIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPeekableIterator")
+
+	iter := NewPeekable(FromSlice([]int{1, 2, 3}))
+
+	item, ok := iter.Peek()
+	assert.Equal(1, item)
+	assert.Equal(true, ok)
+
+	item, _ = iter.PeekAt(2)
+	assert.Equal(3, item)
+
+	_, ok = iter.PeekAt(3)
+	assert.Equal(false, ok)
+
+	assert.Equal([]int{1, 2, 3}, ToSlice[int](iter))
+}
+
+func TestResettableIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestResettableIterator")
+
+	iter := NewResettable(FromSlice([]int{1, 2, 3, 4}))
+
+	iter.Next()
+	iter.Reset()
+	item, _ := iter.Next()
+	assert.Equal(1, item)
+
+	iter.Mark()
+	iter.Next()
+	iter.Next()
+	iter.Reset()
+
+	assert.Equal([]int{2, 3, 4}, ToSlice[int](iter))
+}
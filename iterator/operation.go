@@ -2,14 +2,13 @@
 // Use of this source code is governed by MIT license
 
 // Package iterator provides a way to iterate over values stored in containers.
-// note:
-// 1. Full feature iterator is complicated, this package is just a experiment to explore how iterators could work in Go.
-// 2. The functionality of this package is very simple and limited, may not meet the actual dev needs.
-// 3. It is currently under development, unstable, and will not be completed for some time in the future.
-// So, based on above factors, you may not use it in production. but, anyone is welcome to improve it.
+// Operators like Map, Filter, Zip and Window are lazy, they pull items from the source iterator only when Next is called.
+// Terminal operations like Reduce, Count and GroupBy consume the whole iterator.
 // Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
 package iterator
 
+import "golang.org/x/exp/constraints"
+
 // Map creates a new iterator which applies a function to all items of input iterator.
 func Map[T any, U any](iter Iterator[T], iteratee func(item T) U) Iterator[U] {
 	return &mapIterator[T, U]{
@@ -137,3 +136,395 @@ func (iter *takeIterator[T]) Next() (T, bool) {
 func (iter *takeIterator[T]) HasNext() bool {
 	return iter.num > 0
 }
+
+// Pair holds two values, it is the item type of Zip, ZipLongest and Enumerate.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// funcIterator is an iterator backed by a fetch function. It looks one item ahead,
+// so HasNext is accurate for operators which can't tell whether there is a next item without computing it.
+type funcIterator[T any] struct {
+	fetch  func() (T, bool)
+	item   T
+	loaded bool
+	done   bool
+}
+
+func newFuncIterator[T any](fetch func() (T, bool)) Iterator[T] {
+	return &funcIterator[T]{fetch: fetch}
+}
+
+func (iter *funcIterator[T]) load() {
+	if iter.loaded || iter.done {
+		return
+	}
+
+	item, ok := iter.fetch()
+	if !ok {
+		iter.done = true
+		return
+	}
+	iter.item = item
+	iter.loaded = true
+}
+
+func (iter *funcIterator[T]) HasNext() bool {
+	iter.load()
+	return iter.loaded
+}
+
+func (iter *funcIterator[T]) Next() (T, bool) {
+	var zero T
+
+	iter.load()
+	if !iter.loaded {
+		return zero, false
+	}
+
+	item := iter.item
+	iter.item = zero
+	iter.loaded = false
+
+	return item, true
+}
+
+// Skip creates an iterator that skips the first num items of iter.
+func Skip[T any](iter Iterator[T], num int) Iterator[T] {
+	return newFuncIterator(func() (T, bool) {
+		for ; num > 0; num-- {
+			if _, ok := iter.Next(); !ok {
+				num = 0
+				var zero T
+				return zero, false
+			}
+		}
+		return iter.Next()
+	})
+}
+
+// TakeWhile creates an iterator that returns items of iter while predicate returns true, it stops at the first item that fails.
+func TakeWhile[T any](iter Iterator[T], predicate func(item T) bool) Iterator[T] {
+	done := false
+
+	return newFuncIterator(func() (T, bool) {
+		var zero T
+		if done {
+			return zero, false
+		}
+
+		item, ok := iter.Next()
+		if !ok || !predicate(item) {
+			done = true
+			return zero, false
+		}
+		return item, true
+	})
+}
+
+// DropWhile creates an iterator that skips items of iter while predicate returns true, then returns the rest items.
+func DropWhile[T any](iter Iterator[T], predicate func(item T) bool) Iterator[T] {
+	dropped := false
+
+	return newFuncIterator(func() (T, bool) {
+		if dropped {
+			return iter.Next()
+		}
+
+		dropped = true
+		for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+			if !predicate(item) {
+				return item, true
+			}
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// FlatMap creates an iterator that applies iteratee to every item of iter, and flattens the returned iterators.
+func FlatMap[T any, U any](iter Iterator[T], iteratee func(item T) Iterator[U]) Iterator[U] {
+	var current Iterator[U]
+
+	return newFuncIterator(func() (U, bool) {
+		for {
+			if current != nil {
+				if item, ok := current.Next(); ok {
+					return item, true
+				}
+			}
+
+			next, ok := iter.Next()
+			if !ok {
+				var zero U
+				return zero, false
+			}
+			current = iteratee(next)
+		}
+	})
+}
+
+// Zip creates an iterator that pairs items of iter1 and iter2, it stops when either of them is exhausted.
+func Zip[A any, B any](iter1 Iterator[A], iter2 Iterator[B]) Iterator[Pair[A, B]] {
+	return newFuncIterator(func() (Pair[A, B], bool) {
+		first, ok := iter1.Next()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		second, ok := iter2.Next()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		return Pair[A, B]{First: first, Second: second}, true
+	})
+}
+
+// ZipLongest creates an iterator that pairs items of iter1 and iter2, it stops when both of them are exhausted.
+// Missing items of the shorter iterator are replaced by fill1 or fill2.
+func ZipLongest[A any, B any](iter1 Iterator[A], iter2 Iterator[B], fill1 A, fill2 B) Iterator[Pair[A, B]] {
+	return newFuncIterator(func() (Pair[A, B], bool) {
+		first, ok1 := iter1.Next()
+		second, ok2 := iter2.Next()
+		if !ok1 && !ok2 {
+			return Pair[A, B]{}, false
+		}
+
+		if !ok1 {
+			first = fill1
+		}
+		if !ok2 {
+			second = fill2
+		}
+		return Pair[A, B]{First: first, Second: second}, true
+	})
+}
+
+// Enumerate creates an iterator that pairs every item of iter with its index, starting from 0.
+func Enumerate[T any](iter Iterator[T]) Iterator[Pair[int, T]] {
+	index := 0
+
+	return newFuncIterator(func() (Pair[int, T], bool) {
+		item, ok := iter.Next()
+		if !ok {
+			return Pair[int, T]{}, false
+		}
+		index++
+		return Pair[int, T]{First: index - 1, Second: item}, true
+	})
+}
+
+// Chunk creates an iterator that groups items of iter into slices of size, the last chunk may be shorter.
+func Chunk[T any](iter Iterator[T], size int) Iterator[[]T] {
+	if size <= 0 {
+		panic("Chunk: size should be positive")
+	}
+
+	return newFuncIterator(func() ([]T, bool) {
+		chunk := make([]T, 0, size)
+		for len(chunk) < size {
+			item, ok := iter.Next()
+			if !ok {
+				break
+			}
+			chunk = append(chunk, item)
+		}
+		return chunk, len(chunk) > 0
+	})
+}
+
+// Window creates an iterator of sliding windows over iter, every window has size items and starts one item after the previous one.
+// If iter has less than size items, there is no window.
+func Window[T any](iter Iterator[T], size int) Iterator[[]T] {
+	if size <= 0 {
+		panic("Window: size should be positive")
+	}
+
+	var window []T
+
+	return newFuncIterator(func() ([]T, bool) {
+		if len(window) == size {
+			window = window[1:]
+		}
+		for len(window) < size {
+			item, ok := iter.Next()
+			if !ok {
+				return nil, false
+			}
+			window = append(window, item)
+		}
+
+		result := make([]T, size)
+		copy(result, window)
+		return result, true
+	})
+}
+
+// Distinct creates an iterator that returns items of iter without duplicates, keeping the first occurrence.
+func Distinct[T comparable](iter Iterator[T]) Iterator[T] {
+	return DistinctBy(iter, func(item T) T { return item })
+}
+
+// DistinctBy creates an iterator that returns items of iter whose key is not seen before, keeping the first occurrence.
+func DistinctBy[T any, K comparable](iter Iterator[T], key func(item T) K) Iterator[T] {
+	seen := make(map[K]struct{})
+
+	return newFuncIterator(func() (T, bool) {
+		for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+			k := key(item)
+			if _, exists := seen[k]; !exists {
+				seen[k] = struct{}{}
+				return item, true
+			}
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// Scan creates an iterator that returns every intermediate result of reducing iter with reducer, it is a lazy Reduce.
+func Scan[T any, U any](iter Iterator[T], initial U, reducer func(U, T) U) Iterator[U] {
+	acc := initial
+
+	return newFuncIterator(func() (U, bool) {
+		item, ok := iter.Next()
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		acc = reducer(acc, item)
+		return acc, true
+	})
+}
+
+// Interleave creates an iterator that takes items from iters in turn, exhausted iterators are skipped.
+func Interleave[T any](iters ...Iterator[T]) Iterator[T] {
+	pending := append([]Iterator[T]{}, iters...)
+	i := 0
+
+	return newFuncIterator(func() (T, bool) {
+		for len(pending) > 0 {
+			i %= len(pending)
+			item, ok := pending[i].Next()
+			if ok {
+				i++
+				return item, true
+			}
+			pending = append(pending[:i], pending[i+1:]...)
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// Cycle creates an iterator that repeats the items of iter endlessly, items are buffered during the first pass.
+// If iter is empty, the returned iterator is empty too.
+func Cycle[T any](iter Iterator[T]) Iterator[T] {
+	var buffer []T
+	index := -1
+
+	return newFuncIterator(func() (T, bool) {
+		if index < 0 {
+			if item, ok := iter.Next(); ok {
+				buffer = append(buffer, item)
+				return item, true
+			}
+			index = 0
+		}
+
+		if len(buffer) == 0 {
+			var zero T
+			return zero, false
+		}
+
+		item := buffer[index]
+		index = (index + 1) % len(buffer)
+		return item, true
+	})
+}
+
+// Count consumes iter and returns the number of items.
+func Count[T any](iter Iterator[T]) int {
+	count := 0
+	for _, ok := iter.Next(); ok; _, ok = iter.Next() {
+		count++
+	}
+	return count
+}
+
+// First returns the first item of iter, and false if iter is empty.
+func First[T any](iter Iterator[T]) (T, bool) {
+	return iter.Next()
+}
+
+// Last consumes iter and returns the last item, and false if iter is empty.
+func Last[T any](iter Iterator[T]) (T, bool) {
+	var last T
+	found := false
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		last = item
+		found = true
+	}
+
+	return last, found
+}
+
+// Min consumes iter and returns the minimum item, and false if iter is empty.
+func Min[T constraints.Ordered](iter Iterator[T]) (T, bool) {
+	min, ok := iter.Next()
+	if !ok {
+		return min, false
+	}
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if item < min {
+			min = item
+		}
+	}
+
+	return min, true
+}
+
+// Max consumes iter and returns the maximum item, and false if iter is empty.
+func Max[T constraints.Ordered](iter Iterator[T]) (T, bool) {
+	max, ok := iter.Next()
+	if !ok {
+		return max, false
+	}
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if item > max {
+			max = item
+		}
+	}
+
+	return max, true
+}
+
+// GroupBy consumes iter and groups its items by the key returned by key function, items in a group keep their order.
+func GroupBy[T any, K comparable](iter Iterator[T], key func(item T) K) map[K][]T {
+	result := make(map[K][]T)
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		k := key(item)
+		result[k] = append(result[k], item)
+	}
+
+	return result
+}
+
+// Partition consumes iter and splits its items into the ones that pass predicate and the ones that don't.
+func Partition[T any](iter Iterator[T], predicate func(item T) bool) ([]T, []T) {
+	matched, unmatched := []T{}, []T{}
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+
+	return matched, unmatched
+}
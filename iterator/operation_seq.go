@@ -0,0 +1,384 @@
+//go:build go1.23
+
+package iterator
+
+import (
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MapSeq is the iter.Seq version of Map.
+func MapSeq[T any, U any](seq iter.Seq[T], iteratee func(item T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for item := range seq {
+			if !yield(iteratee(item)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq is the iter.Seq version of Filter.
+func FilterSeq[T any](seq iter.Seq[T], predicateFunc func(item T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if predicateFunc(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// JoinSeq is the iter.Seq version of Join.
+func JoinSeq[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for item := range seq {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ReduceSeq is the iter.Seq version of Reduce.
+func ReduceSeq[T any, U any](seq iter.Seq[T], initial U, reducer func(U, T) U) U {
+	acc := initial
+	for item := range seq {
+		acc = reducer(acc, item)
+	}
+	return acc
+}
+
+// TakeSeq is the iter.Seq version of Take.
+func TakeSeq[T any](seq iter.Seq[T], num int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if num <= 0 {
+			return
+		}
+
+		count := 0
+		for item := range seq {
+			count++
+			if !yield(item) || count >= num {
+				return
+			}
+		}
+	}
+}
+
+// SkipSeq is the iter.Seq version of Skip.
+func SkipSeq[T any](seq iter.Seq[T], num int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		count := 0
+		for item := range seq {
+			count++
+			if count > num && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhileSeq is the iter.Seq version of TakeWhile.
+func TakeWhileSeq[T any](seq iter.Seq[T], predicate func(item T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if !predicate(item) || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhileSeq is the iter.Seq version of DropWhile.
+func DropWhileSeq[T any](seq iter.Seq[T], predicate func(item T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for item := range seq {
+			if dropping && predicate(item) {
+				continue
+			}
+			dropping = false
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapSeq is the iter.Seq version of FlatMap.
+func FlatMapSeq[T any, U any](seq iter.Seq[T], iteratee func(item T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for item := range seq {
+			for sub := range iteratee(item) {
+				if !yield(sub) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ZipSeq is the iter.Seq version of Zip.
+func ZipSeq[A any, B any](seq1 iter.Seq[A], seq2 iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+
+		for first := range seq1 {
+			second, ok := next()
+			if !ok || !yield(Pair[A, B]{First: first, Second: second}) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongestSeq is the iter.Seq version of ZipLongest.
+func ZipLongestSeq[A any, B any](seq1 iter.Seq[A], seq2 iter.Seq[B], fill1 A, fill2 B) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+
+		for first := range seq1 {
+			second, ok := next()
+			if !ok {
+				second = fill2
+			}
+			if !yield(Pair[A, B]{First: first, Second: second}) {
+				return
+			}
+		}
+
+		for second, ok := next(); ok; second, ok = next() {
+			if !yield(Pair[A, B]{First: fill1, Second: second}) {
+				return
+			}
+		}
+	}
+}
+
+// EnumerateSeq is the iter.Seq2 version of Enumerate, it yields index and item.
+func EnumerateSeq[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for item := range seq {
+			if !yield(index, item) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// ChunkSeq is the iter.Seq version of Chunk.
+func ChunkSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size <= 0 {
+		panic("ChunkSeq: size should be positive")
+	}
+
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for item := range seq {
+			chunk = append(chunk, item)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// WindowSeq is the iter.Seq version of Window.
+func WindowSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size <= 0 {
+		panic("WindowSeq: size should be positive")
+	}
+
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		for item := range seq {
+			if len(window) == size {
+				window = window[1:]
+			}
+			window = append(window, item)
+
+			if len(window) == size {
+				result := make([]T, size)
+				copy(result, window)
+				if !yield(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DistinctSeq is the iter.Seq version of Distinct.
+func DistinctSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return DistinctBySeq(seq, func(item T) T { return item })
+}
+
+// DistinctBySeq is the iter.Seq version of DistinctBy.
+func DistinctBySeq[T any, K comparable](seq iter.Seq[T], key func(item T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for item := range seq {
+			k := key(item)
+			if _, exists := seen[k]; exists {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ScanSeq is the iter.Seq version of Scan.
+func ScanSeq[T any, U any](seq iter.Seq[T], initial U, reducer func(U, T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		acc := initial
+		for item := range seq {
+			acc = reducer(acc, item)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// InterleaveSeq is the iter.Seq version of Interleave.
+func InterleaveSeq[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			for i := 0; i < len(nexts); {
+				item, ok := nexts[i]()
+				if !ok {
+					nexts = append(nexts[:i], nexts[i+1:]...)
+					continue
+				}
+				if !yield(item) {
+					return
+				}
+				i++
+			}
+		}
+	}
+}
+
+// CycleSeq is the iter.Seq version of Cycle.
+func CycleSeq[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var buffer []T
+		for item := range seq {
+			buffer = append(buffer, item)
+			if !yield(item) {
+				return
+			}
+		}
+
+		for len(buffer) > 0 {
+			for _, item := range buffer {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CountSeq is the iter.Seq version of Count.
+func CountSeq[T any](seq iter.Seq[T]) int {
+	count := 0
+	for range seq {
+		count++
+	}
+	return count
+}
+
+// FirstSeq is the iter.Seq version of First.
+func FirstSeq[T any](seq iter.Seq[T]) (T, bool) {
+	for item := range seq {
+		return item, true
+	}
+	var zero T
+	return zero, false
+}
+
+// LastSeq is the iter.Seq version of Last.
+func LastSeq[T any](seq iter.Seq[T]) (T, bool) {
+	var last T
+	found := false
+	for item := range seq {
+		last = item
+		found = true
+	}
+	return last, found
+}
+
+// MinSeq is the iter.Seq version of Min.
+func MinSeq[T constraints.Ordered](seq iter.Seq[T]) (T, bool) {
+	var min T
+	found := false
+	for item := range seq {
+		if !found || item < min {
+			min = item
+		}
+		found = true
+	}
+	return min, found
+}
+
+// MaxSeq is the iter.Seq version of Max.
+func MaxSeq[T constraints.Ordered](seq iter.Seq[T]) (T, bool) {
+	var max T
+	found := false
+	for item := range seq {
+		if !found || item > max {
+			max = item
+		}
+		found = true
+	}
+	return max, found
+}
+
+// GroupBySeq is the iter.Seq version of GroupBy.
+func GroupBySeq[T any, K comparable](seq iter.Seq[T], key func(item T) K) map[K][]T {
+	result := make(map[K][]T)
+	for item := range seq {
+		k := key(item)
+		result[k] = append(result[k], item)
+	}
+	return result
+}
+
+// PartitionSeq is the iter.Seq version of Partition.
+func PartitionSeq[T any](seq iter.Seq[T], predicate func(item T) bool) ([]T, []T) {
+	matched, unmatched := []T{}, []T{}
+	for item := range seq {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+	return matched, unmatched
+}
@@ -2,11 +2,8 @@
 // Use of this source code is governed by MIT license
 
 // Package iterator provides a way to iterate over values stored in containers.
-// note:
-// 1. Full feature iterator is complicated, this package is just a experiment to explore how iterators could work in Go.
-// 2. The functionality of this package is very simple and limited, may not meet the actual dev needs.
-// 3. It is currently under development, unstable, and will not be completed for some time in the future.
-// So, based on above factors, you may not use it in production. but, anyone is welcome to improve it.
+// Operators like Map, Filter, Zip and Window are lazy, they pull items from the source iterator only when Next is called.
+// Terminal operations like Reduce, Count and GroupBy consume the whole iterator.
 // Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
 package iterator
 
@@ -71,3 +68,130 @@ func TestTakeIterator(t *testing.T) {
 	result := ToSlice(iter)
 	assert.Equal([]int{1, 2, 3}, result)
 }
+
+func TestSkipIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSkipIterator")
+
+	assert.Equal([]int{3, 4}, ToSlice(Skip(FromSlice([]int{1, 2, 3, 4}), 2)))
+	assert.Equal([]int{}, ToSlice(Skip(FromSlice([]int{1, 2}), 3)))
+}
+
+func TestTakeWhileAndDropWhile(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTakeWhileAndDropWhile")
+
+	lessThan3 := func(n int) bool { return n < 3 }
+
+	iter := TakeWhile(FromSlice([]int{1, 2, 3, 1}), lessThan3)
+	assert.Equal(true, iter.HasNext())
+	assert.Equal([]int{1, 2}, ToSlice(iter))
+	assert.Equal(false, iter.HasNext())
+
+	assert.Equal([]int{3, 1}, ToSlice(DropWhile(FromSlice([]int{1, 2, 3, 1}), lessThan3)))
+}
+
+func TestFlatMapIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFlatMapIterator")
+
+	iter := FlatMap(FromSlice([]int{0, 1, 2, 3}), func(n int) Iterator[int] {
+		return Take(FromRange(0, 10, 1), n)
+	})
+
+	assert.Equal([]int{0, 0, 1, 0, 1, 2}, ToSlice(iter))
+}
+
+func TestZipIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestZipIterator")
+
+	zipped := ToSlice(Zip(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"})))
+	assert.Equal([]Pair[int, string]{{1, "a"}, {2, "b"}}, zipped)
+
+	zipped = ToSlice(ZipLongest(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"}), 0, "-"))
+	assert.Equal([]Pair[int, string]{{1, "a"}, {2, "b"}, {3, "-"}}, zipped)
+}
+
+func TestEnumerateIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestEnumerateIterator")
+
+	result := ToSlice(Enumerate(FromSlice([]string{"a", "b"})))
+	assert.Equal([]Pair[int, string]{{0, "a"}, {1, "b"}}, result)
+}
+
+func TestChunkAndWindow(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChunkAndWindow")
+
+	chunks := ToSlice(Chunk(FromSlice([]int{1, 2, 3, 4, 5}), 2))
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, chunks)
+
+	windows := ToSlice(Window(FromSlice([]int{1, 2, 3, 4}), 3))
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}}, windows)
+
+	assert.Equal([][]int{}, ToSlice(Window(FromSlice([]int{1, 2}), 3)))
+}
+
+func TestDistinctIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDistinctIterator")
+
+	assert.Equal([]int{1, 2, 3}, ToSlice(Distinct(FromSlice([]int{1, 2, 1, 3, 2}))))
+
+	words := DistinctBy(FromSlice([]string{"a", "bb", "c", "dd", "eee"}), func(s string) int { return len(s) })
+	assert.Equal([]string{"a", "bb", "eee"}, ToSlice(words))
+}
+
+func TestScanIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestScanIterator")
+
+	iter := Scan(FromSlice([]int{1, 2, 3, 4}), 0, func(acc, n int) int { return acc + n })
+	assert.Equal([]int{1, 3, 6, 10}, ToSlice(iter))
+}
+
+func TestInterleaveIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestInterleaveIterator")
+
+	iter := Interleave(FromSlice([]int{1, 4, 6}), FromSlice([]int{2}), FromSlice([]int{3, 5}))
+	assert.Equal([]int{1, 2, 3, 4, 5, 6}, ToSlice(iter))
+}
+
+func TestCycleIterator(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCycleIterator")
+
+	iter := Take(Cycle(FromSlice([]int{1, 2, 3})), 7)
+	assert.Equal([]int{1, 2, 3, 1, 2, 3, 1}, ToSlice(iter))
+
+	assert.Equal(false, Cycle(FromSlice([]int{})).HasNext())
+}
+
+func TestTerminalOperations(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTerminalOperations")
+
+	assert.Equal(4, Count(FromSlice([]int{3, 1, 4, 2})))
+
+	first, ok := First(FromSlice([]int{3, 1, 4, 2}))
+	assert.Equal(3, first)
+	assert.Equal(true, ok)
+
+	last, ok := Last(FromSlice([]int{3, 1, 4, 2}))
+	assert.Equal(2, last)
+	assert.Equal(true, ok)
+
+	min, _ := Min(FromSlice([]int{3, 1, 4, 2}))
+	assert.Equal(1, min)
+
+	max, _ := Max(FromSlice([]int{3, 1, 4, 2}))
+	assert.Equal(4, max)
+
+	_, ok = Max(FromSlice([]int{}))
+	assert.Equal(false, ok)
+}
+
+func TestGroupByAndPartition(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGroupByAndPartition")
+
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	groups := GroupBy(FromSlice([]int{1, 2, 3, 4, 5}), isEven)
+	assert.Equal(map[bool][]int{true: {2, 4}, false: {1, 3, 5}}, groups)
+
+	even, odd := Partition(FromSlice([]int{1, 2, 3, 4, 5}), isEven)
+	assert.Equal([]int{2, 4}, even)
+	assert.Equal([]int{1, 3, 5}, odd)
+}
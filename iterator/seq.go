@@ -0,0 +1,75 @@
+//go:build go1.23
+
+package iterator
+
+import "iter"
+
+// ToSeq returns an iter.Seq which yields the rest items of it, so it can be used in a for range loop.
+// Breaking the loop stops pulling items, and stops it if it is a StopIterator.
+func ToSeq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item, ok := it.Next(); ok; item, ok = it.Next() {
+			if !yield(item) {
+				stopIterator(it)
+				return
+			}
+		}
+	}
+}
+
+// ToSeq2 returns an iter.Seq2 which yields the First and Second of the rest pairs of it, eg. the result of Enumerate or NewMapIterator.
+func ToSeq2[K any, V any](it Iterator[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for item, ok := it.Next(); ok; item, ok = it.Next() {
+			if !yield(item.First, item.Second) {
+				stopIterator(it)
+				return
+			}
+		}
+	}
+}
+
+// FromSeq returns an iterator which pulls items from seq.
+// Call Stop if the iterator is not exhausted, so the resources of seq are released.
+func FromSeq[T any](seq iter.Seq[T]) StopIterator[T] {
+	next, stop := iter.Pull(seq)
+
+	it := &seqIterator[T]{stop: stop}
+	it.fetch = next
+
+	return it
+}
+
+// FromSeq2 returns an iterator which pulls key value pairs from seq.
+// Call Stop if the iterator is not exhausted, so the resources of seq are released.
+func FromSeq2[K any, V any](seq iter.Seq2[K, V]) StopIterator[Pair[K, V]] {
+	next, stop := iter.Pull2(seq)
+
+	it := &seqIterator[Pair[K, V]]{stop: stop}
+	it.fetch = func() (Pair[K, V], bool) {
+		k, v, ok := next()
+		return Pair[K, V]{First: k, Second: v}, ok
+	}
+
+	return it
+}
+
+type seqIterator[T any] struct {
+	funcIterator[T]
+	stop func()
+}
+
+// Stop implements StopIterator.
+func (it *seqIterator[T]) Stop() {
+	var zero T
+	it.item = zero
+	it.loaded = false
+	it.done = true
+	it.stop()
+}
+
+func stopIterator[T any](it Iterator[T]) {
+	if stopper, ok := it.(StopIterator[T]); ok {
+		stopper.Stop()
+	}
+}
@@ -0,0 +1,78 @@
+//go:build go1.23
+
+package iterator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestToSeqAndFromSeq(t *testing.T) {
+	assert := internal.NewAssert(t, "TestToSeqAndFromSeq")
+
+	result := []int{}
+	for item := range ToSeq(FromSlice([]int{1, 2, 3, 4})) {
+		if item == 3 {
+			break
+		}
+		result = append(result, item)
+	}
+	assert.Equal([]int{1, 2}, result)
+
+	iter := FromSeq(slices.Values([]int{1, 2, 3}))
+	assert.Equal(true, iter.HasNext())
+	item, _ := iter.Next()
+	assert.Equal(1, item)
+
+	iter.Stop()
+	assert.Equal(false, iter.HasNext())
+	_, ok := iter.Next()
+	assert.Equal(false, ok)
+}
+
+func TestToSeq2AndFromSeq2(t *testing.T) {
+	assert := internal.NewAssert(t, "TestToSeq2AndFromSeq2")
+
+	result := map[int]string{}
+	for i, s := range ToSeq2(Enumerate(FromSlice([]string{"a", "b"}))) {
+		result[i] = s
+	}
+	assert.Equal(map[int]string{0: "a", 1: "b"}, result)
+
+	pairs := ToSlice[Pair[int, string]](FromSeq2(slices.All([]string{"a", "b"})))
+	assert.Equal([]Pair[int, string]{{0, "a"}, {1, "b"}}, pairs)
+}
+
+func TestOperationSeq(t *testing.T) {
+	assert := internal.NewAssert(t, "TestOperationSeq")
+
+	numbers := slices.Values([]int{1, 2, 3, 4, 5})
+	isOdd := func(n int) bool { return n%2 == 1 }
+
+	assert.Equal([]int{2, 6, 10}, slices.Collect(MapSeq(FilterSeq(numbers, isOdd), func(n int) int { return n * 2 })))
+	assert.Equal([]int{2, 3}, slices.Collect(TakeSeq(SkipSeq(numbers, 1), 2)))
+	assert.Equal([]int{3, 4, 5}, slices.Collect(DropWhileSeq(numbers, func(n int) bool { return n < 3 })))
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(ChunkSeq(numbers, 2)))
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, slices.Collect(WindowSeq(numbers, 3)))
+	assert.Equal([]int{1, 3, 6, 10, 15}, slices.Collect(ScanSeq(numbers, 0, func(acc, n int) int { return acc + n })))
+	assert.Equal([]int{1, 2, 3, 1, 2}, slices.Collect(TakeSeq(CycleSeq(TakeSeq(numbers, 3)), 5)))
+	assert.Equal(15, ReduceSeq(numbers, 0, func(acc, n int) int { return acc + n }))
+
+	zipped := slices.Collect(ZipLongestSeq(numbers, slices.Values([]string{"a"}), 0, "-"))
+	assert.Equal(Pair[int, string]{1, "a"}, zipped[0])
+	assert.Equal(Pair[int, string]{5, "-"}, zipped[4])
+
+	interleaved := slices.Collect(InterleaveSeq(slices.Values([]int{1, 3, 5}), slices.Values([]int{2})))
+	assert.Equal([]int{1, 2, 3, 5}, interleaved)
+
+	min, _ := MinSeq(numbers)
+	max, _ := MaxSeq(numbers)
+	assert.Equal(1, min)
+	assert.Equal(5, max)
+
+	odd, even := PartitionSeq(numbers, isOdd)
+	assert.Equal([]int{1, 3, 5}, odd)
+	assert.Equal([]int{2, 4}, even)
+}
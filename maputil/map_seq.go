@@ -0,0 +1,69 @@
+//go:build go1.23
+
+package maputil
+
+import "iter"
+
+// KeysSeq returns an iter.Seq of the map's keys, the order is not specified.
+func KeysSeq[K comparable, V any](m map[K]V) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iter.Seq of the map's values, the order is not specified.
+func ValuesSeq[K comparable, V any](m map[K]V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// EntriesSeq returns an iter.Seq of the map's entries, the order is not specified.
+func EntriesSeq[K comparable, V any](m map[K]V) iter.Seq[Entry[K, V]] {
+	return func(yield func(Entry[K, V]) bool) {
+		for k, v := range m {
+			if !yield(Entry[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq is the lazy version of Filter, it returns an iter.Seq2 of the key and value pairs which pass predicate.
+func FilterSeq[K comparable, V any](m map[K]V, predicate func(key K, value V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if predicate(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// TransformSeq is the lazy version of Transform, it returns an iter.Seq2 of the transformed key and value pairs.
+func TransformSeq[K1 comparable, V1 any, K2 comparable, V2 any](m map[K1]V1, iteratee func(key K1, value V1) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range m {
+			if !yield(iteratee(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq2 collects the key and value pairs of seq into a new map, later pairs overwrite earlier ones with the same key.
+func FromSeq2[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	result := make(map[K]V)
+	for k, v := range seq {
+		result[k] = v
+	}
+	return result
+}
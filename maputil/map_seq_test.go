@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package maputil
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestMapSeq(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMapSeq")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	filtered := FromSeq2(FilterSeq(m, func(_ string, v int) bool { return v > 1 }))
+	assert.Equal(map[string]int{"b": 2, "c": 3}, filtered)
+
+	inverted := FromSeq2(TransformSeq(m, func(k string, v int) (int, string) { return v, k }))
+	assert.Equal(map[int]string{1: "a", 2: "b", 3: "c"}, inverted)
+
+	sum := 0
+	for v := range ValuesSeq(m) {
+		sum += v
+	}
+	assert.Equal(6, sum)
+}
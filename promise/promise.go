@@ -5,25 +5,36 @@
 package promise
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"sync"
+	"time"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/xerror"
 )
 
+// ErrTimeout is the error of a promise rejected by Timeout.
+var ErrTimeout = errors.New("promise timeout")
+
 // Promise represents the eventual completion (or failure) of an asynchronous operation and its resulting value.
 // ref : chebyrash/promise (https://github.com/chebyrash/promise)
 // see js promise: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise
 type Promise[T any] struct {
-	runnable func(resolve func(T), reject func(error))
-	result   T
-	err      error
+	result T
+	err    error
 
 	pending bool
 
-	mu *sync.Mutex
-	wg *sync.WaitGroup
+	// ctx is inherited by the promises chained with Then and Catch, a promise is rejected with ctx.Err() when ctx is done.
+	// watcher is shared by the promises with the same ctx, it is nil if ctx can never be done.
+	ctx     context.Context
+	watcher *ctxWatcher
+	done    chan struct{}
+	mu      *sync.Mutex
+
+	// callbacks are called once the promise is settled, they settle the promises chained from it.
+	callbacks []func()
 }
 
 // New create a new promise instance.
@@ -32,88 +43,237 @@ func New[T any](runnable func(resolve func(T), reject func(error))) *Promise[T]
 		panic("runnable function should not be nil")
 	}
 
-	p := &Promise[T]{
-		runnable: runnable,
-		pending:  true,
-		mu:       &sync.Mutex{},
-		wg:       &sync.WaitGroup{},
-	}
+	return NewWithContext(context.Background(), func(_ context.Context, resolve func(T), reject func(error)) {
+		runnable(resolve, reject)
+	})
+}
 
-	defer p.run()
+// NewWithContext create a new promise instance which is rejected with ctx.Err() if ctx is done before it is settled.
+// runnable should watch ctx to stop its work, the promises chained from it with Then and Catch share ctx.
+func NewWithContext[T any](ctx context.Context, runnable func(ctx context.Context, resolve func(T), reject func(error))) *Promise[T] {
+	if runnable == nil {
+		panic("runnable function should not be nil")
+	}
 
-	return p
-}
+	p := newPromise[T](ctx, newCtxWatcher(ctx))
 
-func (p *Promise[T]) run() {
-	p.wg.Add(1)
+	if err := ctx.Err(); err != nil {
+		p.reject(err)
+		return p
+	}
 
 	go func() {
-		defer func() {
-			if !p.pending {
-				return
-			}
+		defer p.recover()
+		runnable(ctx, p.resolve, p.reject)
+	}()
 
-			if err := recover(); err != nil {
-				p.reject(errors.New(fmt.Sprint(err)))
-			}
-		}()
+	p.watch()
 
-		p.runnable(p.resolve, p.reject)
-	}()
+	return p
 }
 
-// Resolve returns a Promise that has been resolved with a given value.
-func Resolve[T any](resolution T) *Promise[T] {
+func newPromise[T any](ctx context.Context, watcher *ctxWatcher) *Promise[T] {
 	return &Promise[T]{
-		result:  resolution,
-		pending: false,
+		pending: true,
+		ctx:     ctx,
+		watcher: watcher,
+		done:    make(chan struct{}),
 		mu:      &sync.Mutex{},
-		wg:      &sync.WaitGroup{},
 	}
 }
 
-func (p *Promise[T]) resolve(value T) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+// watch rejects the promise with ctx.Err() when its context is done before it is settled.
+func (p *Promise[T]) watch() {
+	if p.watcher == nil {
+		return
+	}
 
-	if !p.pending {
+	p.onSettled(p.watcher.add(p.reject))
+}
+
+// ctxWatcher rejects the pending promises sharing a context when the context is done.
+// A promise created by NewWithContext and all promises chained from it share one ctxWatcher,
+// which keeps a single goroutine only while some of them are pending.
+type ctxWatcher struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	next    int
+	rejects map[int]func(error)
+	// stop is closed when no promise is pending, it is nil when there is no goroutine
+	stop chan struct{}
+}
+
+// newCtxWatcher returns a ctxWatcher of ctx, or nil if ctx can never be done.
+func newCtxWatcher(ctx context.Context) *ctxWatcher {
+	if ctx.Done() == nil {
+		return nil
+	}
+
+	return &ctxWatcher{ctx: ctx, rejects: make(map[int]func(error))}
+}
+
+// add registers the reject function of a pending promise, the returned function should be called once the promise is settled.
+func (w *ctxWatcher) add(reject func(error)) (remove func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.next
+	w.next++
+	w.rejects[id] = reject
+
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		go w.run(w.stop)
+	}
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.rejects, id)
+		if len(w.rejects) == 0 && w.stop != nil {
+			close(w.stop)
+			w.stop = nil
+		}
+	}
+}
+
+func (w *ctxWatcher) run(stop chan struct{}) {
+	select {
+	case <-stop:
 		return
+	case <-w.ctx.Done():
 	}
 
-	p.result = value
-	p.pending = false
+	w.mu.Lock()
+	rejects := make([]func(error), 0, len(w.rejects))
+	for _, reject := range w.rejects {
+		rejects = append(rejects, reject)
+	}
+	w.rejects = make(map[int]func(error))
+	if w.stop == stop {
+		w.stop = nil
+	}
+	w.mu.Unlock()
+
+	for _, reject := range rejects {
+		reject(w.ctx.Err())
+	}
+}
+
+// onSettled registers fn to be called once the promise is settled, fn is called at once if it is already settled.
+func (p *Promise[T]) onSettled(fn func()) {
+	p.mu.Lock()
+	if p.pending {
+		p.callbacks = append(p.callbacks, fn)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
 
-	p.wg.Done()
+	fn()
+}
+
+// settledPromise returns a promise which is already settled.
+func settledPromise[T any](result T, err error) *Promise[T] {
+	p := &Promise[T]{
+		result: result,
+		err:    err,
+		ctx:    context.Background(),
+		done:   make(chan struct{}),
+		mu:     &sync.Mutex{},
+	}
+	close(p.done)
+
+	return p
+}
+
+// recover rejects the promise with the recovered panic, it should be deferred directly.
+// The rejection is a *xerror.XError carrying the stack of the panic, the panic value is kept under the key "panic".
+// If the panic value is an error, it is wrapped, so errors.Is and errors.As still reach it.
+func (p *Promise[T]) recover() {
+	if r := recover(); r != nil {
+		var err *xerror.XError
+		if e, ok := r.(error); ok {
+			err = xerror.Wrap(e, "panic")
+		} else {
+			err = xerror.New("%v", r)
+		}
+		p.reject(err.With("panic", r))
+	}
+}
+
+// Resolve returns a Promise that has been resolved with a given value.
+func Resolve[T any](resolution T) *Promise[T] {
+	return settledPromise(resolution, nil)
+}
+
+func (p *Promise[T]) resolve(value T) {
+	p.complete(value, nil)
 }
 
 // Reject returns a Promise that has been rejected with a given error.
 func Reject[T any](err error) *Promise[T] {
-	return &Promise[T]{
-		err:     err,
-		pending: false,
-		mu:      &sync.Mutex{},
-		wg:      &sync.WaitGroup{},
-	}
+	var zeroValue T
+	return settledPromise(zeroValue, err)
 }
 
 func (p *Promise[T]) reject(err error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	var zeroValue T
+	p.complete(zeroValue, err)
+}
 
+func (p *Promise[T]) complete(value T, err error) {
+	p.mu.Lock()
 	if !p.pending {
+		p.mu.Unlock()
 		return
 	}
 
+	p.result = value
 	p.err = err
 	p.pending = false
+	callbacks := p.callbacks
+	p.callbacks = nil
+
+	close(p.done)
+	p.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// settle settles the promise with the outcome of a settled promise.
+func settle[T any](p *Promise[T], from *Promise[T]) {
+	if from.err != nil {
+		p.reject(from.err)
+		return
+	}
+	p.resolve(from.result)
+}
+
+// chain creates a promise which is settled by fn once promise is settled, or rejected when the context of promise is done.
+// fn is registered as a callback of promise and runs in its own goroutine only after promise is settled,
+// and the cancellation of the whole chain is watched by the one goroutine of its ctxWatcher.
+func chain[T1, T2 any](promise *Promise[T1], fn func(value T1, err error, resolve func(T2), reject func(error))) *Promise[T2] {
+	p := newPromise[T2](promise.ctx, promise.watcher)
 
-	p.wg.Done()
+	promise.onSettled(func() {
+		go func() {
+			defer p.recover()
+			fn(promise.result, promise.err, p.resolve, p.reject)
+		}()
+	})
+
+	p.watch()
+
+	return p
 }
 
 // Then allows chain calls to other promise methods.
 func Then[T1, T2 any](promise *Promise[T1], resolve1 func(value T1) T2) *Promise[T2] {
-	return New(func(resolve2 func(T2), reject func(error)) {
-		result, err := promise.Await()
+	return chain(promise, func(result T1, err error, resolve2 func(T2), reject func(error)) {
 		if err != nil {
 			reject(err)
 			return
@@ -124,20 +284,57 @@ func Then[T1, T2 any](promise *Promise[T1], resolve1 func(value T1) T2) *Promise
 
 // Then allows chain calls to other promise methods.
 func (p *Promise[T]) Then(resolve func(value T) T) *Promise[T] {
-	return New(func(_resolve func(T), reject func(error)) {
-		result, err := p.Await()
+	return Then(p, resolve)
+}
+
+// ThenErr is like Then, but resolve1 may fail, the returned promise is rejected with its error.
+func ThenErr[T1, T2 any](promise *Promise[T1], resolve1 func(value T1) (T2, error)) *Promise[T2] {
+	return chain(promise, func(result T1, err error, resolve2 func(T2), reject func(error)) {
 		if err != nil {
 			reject(err)
 			return
 		}
-		_resolve(resolve(result))
+
+		value, err := resolve1(result)
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve2(value)
 	})
 }
 
+// ThenErr is like Then, but resolve may fail, the returned promise is rejected with its error.
+func (p *Promise[T]) ThenErr(resolve func(value T) (T, error)) *Promise[T] {
+	return ThenErr(p, resolve)
+}
+
+// Recover returns a promise which resolves with the value of promise, or with the value recovered from its error by rescue.
+// If rescue returns an error, the returned promise is rejected with it.
+func Recover[T any](promise *Promise[T], rescue func(err error) (T, error)) *Promise[T] {
+	return chain(promise, func(result T, err error, resolve func(T), reject func(error)) {
+		if err == nil {
+			resolve(result)
+			return
+		}
+
+		value, err := rescue(err)
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(value)
+	})
+}
+
+// Recover turns the rejection of the promise into a value with rescue.
+func (p *Promise[T]) Recover(rescue func(err error) (T, error)) *Promise[T] {
+	return Recover(p, rescue)
+}
+
 // Catch allows to chain promises.
 func Catch[T any](promise *Promise[T], rejection func(err error) error) *Promise[T] {
-	return New(func(resolve func(T), reject func(error)) {
-		result, err := promise.Await()
+	return chain(promise, func(result T, err error, resolve func(T), reject func(error)) {
 		if err != nil {
 			reject(rejection(err))
 			return
@@ -148,25 +345,45 @@ func Catch[T any](promise *Promise[T], rejection func(err error) error) *Promise
 
 // Catch chain an existing promise with an intermediate reject function.
 func (p *Promise[T]) Catch(reject func(error) error) *Promise[T] {
-	return New(func(resolve func(T), rej func(error)) {
-		resutl, err := p.Await()
-		if err != nil {
-			rej(reject(err))
-			return
+	return Catch(p, reject)
+}
+
+// Timeout returns a promise which is settled as promise, or rejected with ErrTimeout if promise is not settled within d.
+func Timeout[T any](promise *Promise[T], d time.Duration) *Promise[T] {
+	p := newPromise[T](promise.ctx, promise.watcher)
+
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-promise.done:
+			settle(p, promise)
+		case <-timer.C:
+			p.reject(ErrTimeout)
+		case <-promise.ctx.Done():
+			p.reject(promise.ctx.Err())
 		}
-		resolve(resutl)
-	})
+	}()
+
+	return p
 }
 
 // Await blocks until the 'runable' to finish execution.
 func (p *Promise[T]) Await() (T, error) {
-	p.wg.Wait()
+	<-p.done
 	return p.result, p.err
 }
 
-type tuple[T1, T2 any] struct {
-	_1 T1
-	_2 T2
+// AwaitContext blocks until the promise is settled or ctx is done, it returns ctx.Err() if ctx is done first.
+func (p *Promise[T]) AwaitContext(ctx context.Context) (T, error) {
+	select {
+	case <-p.done:
+		return p.result, p.err
+	case <-ctx.Done():
+		var zeroValue T
+		return zeroValue, ctx.Err()
+	}
 }
 
 // All resolves when all of the promises have resolved, reject immediately upon any of the input promises rejecting.
@@ -175,34 +392,38 @@ func All[T any](promises []*Promise[T]) *Promise[[]T] {
 		return nil
 	}
 
-	return New(func(resolve func([]T), reject func(error)) {
-		valsChan := make(chan tuple[T, int], len(promises))
-		errsChan := make(chan error, 1)
-
-		for idx, p := range promises {
-			idx := idx
-			_ = Then(p, func(data T) T {
-				valsChan <- tuple[T, int]{_1: data, _2: idx}
-				return data
-			})
-			_ = Catch(p, func(err error) error {
-				errsChan <- err
-				return err
-			})
-		}
+	p := newPromise[[]T](context.Background(), nil)
+
+	var mu sync.Mutex
+	resolutions := make([]T, len(promises))
+	count := 0
 
-		resolutions := make([]T, len(promises))
-		for idx := 0; idx < len(promises); idx++ {
+	for idx, promise := range promises {
+		go func(idx int, promise *Promise[T]) {
 			select {
-			case val := <-valsChan:
-				resolutions[val._2] = val._1
-			case err := <-errsChan:
-				reject(err)
+			case <-promise.done:
+			case <-p.done:
 				return
 			}
-		}
-		resolve(resolutions)
-	})
+
+			if promise.err != nil {
+				p.reject(promise.err)
+				return
+			}
+
+			mu.Lock()
+			resolutions[idx] = promise.result
+			count++
+			finished := count == len(promises)
+			mu.Unlock()
+
+			if finished {
+				p.resolve(resolutions)
+			}
+		}(idx, promise)
+	}
+
+	return p
 }
 
 // Race will settle the first fullfiled promise among muti promises.
@@ -211,28 +432,19 @@ func Race[T any](promises []*Promise[T]) *Promise[T] {
 		return nil
 	}
 
-	return New(func(resolve func(T), reject func(error)) {
-		valsChan := make(chan T, 1)
-		errsChan := make(chan error, 1)
-
-		for _, p := range promises {
-			_ = Then(p, func(data T) T {
-				valsChan <- data
-				return data
-			})
-			_ = Catch(p, func(err error) error {
-				errsChan <- err
-				return err
-			})
-		}
+	p := newPromise[T](context.Background(), nil)
 
-		select {
-		case val := <-valsChan:
-			resolve(val)
-		case err := <-errsChan:
-			reject(err)
-		}
-	})
+	for _, promise := range promises {
+		go func(promise *Promise[T]) {
+			select {
+			case <-promise.done:
+				settle(p, promise)
+			case <-p.done:
+			}
+		}(promise)
+	}
+
+	return p
 }
 
 // Any resolves as soon as any of the input's Promises resolve, with the value of the resolved Promise.
@@ -242,38 +454,216 @@ func Any[T any](promises []*Promise[T]) *Promise[T] {
 		return nil
 	}
 
-	return New(func(resolve func(T), reject func(error)) {
-		valsChan := make(chan T, 1)
-		errsChan := make(chan tuple[error, int], len(promises))
-
-		for idx, p := range promises {
-			idx := idx
-			_ = Then(p, func(data T) T {
-				valsChan <- data
-				return data
-			})
-			_ = Catch(p, func(err error) error {
-				errsChan <- tuple[error, int]{_1: err, _2: idx}
-				return err
-			})
-		}
+	p := newPromise[T](context.Background(), nil)
 
-		errs := make([]error, len(promises))
-		for idx := 0; idx < len(promises); idx++ {
+	var mu sync.Mutex
+	errs := make([]error, len(promises))
+	count := 0
+
+	for idx, promise := range promises {
+		go func(idx int, promise *Promise[T]) {
 			select {
-			case val := <-valsChan:
-				resolve(val)
+			case <-promise.done:
+			case <-p.done:
+				return
+			}
+
+			if promise.err == nil {
+				p.resolve(promise.result)
 				return
-			case err := <-errsChan:
-				errs[err._2] = err._1
 			}
+
+			mu.Lock()
+			errs[idx] = promise.err
+			count++
+			finished := count == len(promises)
+			mu.Unlock()
+
+			if finished {
+				p.reject(internal.JoinError(errs...))
+			}
+		}(idx, promise)
+	}
+
+	return p
+}
+
+// SettledResult is the outcome of a promise given by AllSettled, Err is nil if the promise is resolved.
+type SettledResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// AllSettled resolves when all of the promises have settled, with the outcome of every promise in the input order.
+// It never rejects.
+func AllSettled[T any](promises []*Promise[T]) *Promise[[]SettledResult[T]] {
+	if len(promises) == 0 {
+		return nil
+	}
+
+	p := newPromise[[]SettledResult[T]](context.Background(), nil)
+
+	go func() {
+		results := make([]SettledResult[T], len(promises))
+		for idx, promise := range promises {
+			value, err := promise.Await()
+			results[idx] = SettledResult[T]{Value: value, Err: err}
 		}
+		p.resolve(results)
+	}()
 
-		errCombo := errs[0]
-		for _, err := range errs[1:] {
-			errCombo = internal.JoinError(err)
+	return p
+}
+
+// Finally returns a promise which calls fn once promise is settled, then settles with the outcome of promise.
+func Finally[T any](promise *Promise[T], fn func()) *Promise[T] {
+	return chain(promise, func(result T, err error, resolve func(T), reject func(error)) {
+		fn()
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(result)
+	})
+}
+
+// Finally calls fn once the promise is settled, the returned promise settles with the outcome of the promise.
+func (p *Promise[T]) Finally(fn func()) *Promise[T] {
+	return Finally(p, fn)
+}
+
+// MapLimit creates a promise with fn for every item, at most limit of them are pending at the same time,
+// limit <= 0 means no limit. It resolves with the results in the order of items, or rejects with the first error,
+// after which no new promise is created.
+func MapLimit[T, U any](items []T, limit int, fn func(item T) *Promise[U]) *Promise[[]U] {
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+
+	p := newPromise[[]U](context.Background(), nil)
+
+	go func() {
+		defer p.recover()
+
+		results := make([]U, len(items))
+		sem := make(chan struct{}, limit)
+		var wg sync.WaitGroup
+
+	loop:
+		for idx, item := range items {
+			select {
+			case sem <- struct{}{}:
+			case <-p.done:
+				break loop
+			}
+
+			// select picks at random when both are ready, recheck so fn is not called after the rejection
+			select {
+			case <-p.done:
+				<-sem
+				break loop
+			default:
+			}
+
+			promise := fn(item)
+
+			wg.Add(1)
+			go func(idx int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				select {
+				case <-promise.done:
+					if promise.err != nil {
+						p.reject(promise.err)
+						return
+					}
+					results[idx] = promise.result
+				case <-p.done:
+				}
+			}(idx)
+		}
+
+		wg.Wait()
+		p.resolve(results)
+	}()
+
+	return p
+}
+
+// Sequence calls the factories one after another, every factory is called after the promise of the previous one is resolved.
+// It resolves with the results in order, or rejects with the first error, the remaining factories are not called.
+func Sequence[T any](factories []func() *Promise[T]) *Promise[[]T] {
+	p := newPromise[[]T](context.Background(), nil)
+
+	go func() {
+		defer p.recover()
+
+		results := make([]T, 0, len(factories))
+		for _, factory := range factories {
+			result, err := factory().Await()
+			if err != nil {
+				p.reject(err)
+				return
+			}
+			results = append(results, result)
 		}
+		p.resolve(results)
+	}()
+
+	return p
+}
 
-		reject(errCombo)
+// Delay returns a promise which resolves with value after d.
+func Delay[T any](d time.Duration, value T) *Promise[T] {
+	p := newPromise[T](context.Background(), nil)
+
+	time.AfterFunc(d, func() {
+		p.resolve(value)
 	})
+
+	return p
+}
+
+// Retry calls factory until its promise resolves or it has been called attempts times, it waits delay before the first retry
+// and doubles the wait before every next retry. It rejects with the error of the last attempt,
+// or with ctx.Err() if the context of the promise of the last attempt is done while waiting.
+func Retry[T any](factory func() *Promise[T], attempts int, delay time.Duration) *Promise[T] {
+	if attempts <= 0 {
+		panic("Retry: attempts should be positive")
+	}
+
+	p := newPromise[T](context.Background(), nil)
+
+	go func() {
+		defer p.recover()
+
+		wait := delay
+		for i := 1; ; i++ {
+			promise := factory()
+			result, err := promise.Await()
+			if err == nil {
+				p.resolve(result)
+				return
+			}
+			if i == attempts {
+				p.reject(err)
+				return
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-promise.ctx.Done():
+				timer.Stop()
+				p.reject(promise.ctx.Err())
+				return
+			}
+			wait *= 2
+		}
+	}()
+
+	return p
 }
@@ -1,8 +1,10 @@
 package promise
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/serialt/lancet/internal"
@@ -193,3 +195,109 @@ func ExampleRace() {
 	// Output:
 	// fast
 }
+
+func ExampleTimeout() {
+	p := New(func(resolve func(string), reject func(error)) {
+		time.Sleep(time.Millisecond * 100)
+		resolve("slow")
+	})
+
+	_, err := Timeout(p, time.Millisecond*10).Await()
+
+	fmt.Println(err)
+
+	// Output:
+	// promise timeout
+}
+
+func ExampleNewWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := NewWithContext(ctx, func(ctx context.Context, resolve func(string), reject func(error)) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			resolve("done")
+		}
+	})
+
+	cancel()
+	_, err := p.Await()
+
+	fmt.Println(err)
+
+	// Output:
+	// context canceled
+}
+
+func ExampleAllSettled() {
+	p1 := Resolve("a")
+	p2 := Reject[string](errors.New("error"))
+
+	results, _ := AllSettled([]*Promise[string]{p1, p2}).Await()
+
+	for _, result := range results {
+		fmt.Printf("value: %q, err: %v\n", result.Value, result.Err)
+	}
+
+	// Output:
+	// value: "a", err: <nil>
+	// value: "", err: error
+}
+
+func ExampleMapLimit() {
+	p := MapLimit([]int{1, 2, 3}, 2, func(n int) *Promise[int] {
+		return Resolve(n * 10)
+	})
+
+	result, _ := p.Await()
+
+	fmt.Println(result)
+
+	// Output:
+	// [10 20 30]
+}
+
+func ExampleRetry() {
+	attempts := 0
+	p := Retry(func() *Promise[string] {
+		attempts++
+		if attempts < 3 {
+			return Reject[string](errors.New("error"))
+		}
+		return Resolve("ok")
+	}, 5, time.Millisecond)
+
+	result, err := p.Await()
+
+	fmt.Println(result, err, attempts)
+
+	// Output:
+	// ok <nil> 3
+}
+
+func ExampleThenErr() {
+	p := ThenErr(Resolve("42"), func(s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+
+	result, err := p.Await()
+
+	fmt.Println(result, err)
+
+	// Output:
+	// 42 <nil>
+}
+
+func ExampleRecover() {
+	p := Recover(Reject[string](errors.New("not found")), func(err error) (string, error) {
+		return "default", nil
+	})
+
+	result, err := p.Await()
+
+	fmt.Println(result, err)
+
+	// Output:
+	// default <nil>
+}
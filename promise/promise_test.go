@@ -1,11 +1,15 @@
 package promise
 
 import (
+	"context"
 	"errors"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/serialt/lancet/internal"
+	"github.com/serialt/lancet/xerror"
 )
 
 func TestResolve(t *testing.T) {
@@ -300,3 +304,366 @@ func TestRace(t *testing.T) {
 	})
 
 }
+
+func TestNewWithContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestNewWithContext")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stopped := make(chan struct{})
+	p := NewWithContext(ctx, func(ctx context.Context, resolve func(int), reject func(error)) {
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	// the chained promises are cancelled together with the root one
+	p2 := p.Then(func(n int) int { return n + 1 })
+	p3 := Catch(p2, func(err error) error { return err })
+
+	cancel()
+
+	_, err := p.Await()
+	assert.Equal(context.Canceled, err)
+	_, err = p3.Await()
+	assert.Equal(context.Canceled, err)
+	<-stopped
+
+	// a done context rejects at once
+	p4 := NewWithContext(ctx, func(ctx context.Context, resolve func(int), reject func(error)) {
+		resolve(1)
+	})
+	_, err = p4.Await()
+	assert.Equal(context.Canceled, err)
+}
+
+func TestNewWithContext_Resolved(t *testing.T) {
+	assert := internal.NewAssert(t, "TestNewWithContext_Resolved")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewWithContext(ctx, func(ctx context.Context, resolve func(string), reject func(error)) {
+		resolve("abc")
+	})
+
+	val, err := Then(p, func(s string) int { return len(s) }).Await()
+	assert.IsNil(err)
+	assert.Equal(3, val)
+}
+
+func TestAwaitContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestAwaitContext")
+
+	p := New(func(resolve func(int), reject func(error)) {
+		time.Sleep(50 * time.Millisecond)
+		resolve(1)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := p.AwaitContext(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	val, err := p.AwaitContext(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, val)
+}
+
+func TestTimeout(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTimeout")
+
+	never := New(func(resolve func(int), reject func(error)) {})
+
+	_, err := Timeout(never, 10*time.Millisecond).Await()
+	assert.Equal(ErrTimeout, err)
+
+	val, err := Timeout(Resolve(2), time.Second).Await()
+	assert.IsNil(err)
+	assert.Equal(2, val)
+}
+
+func TestChain_ReleaseGoroutines(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChain_ReleaseGoroutines")
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewWithContext(ctx, func(ctx context.Context, resolve func(int), reject func(error)) {
+		<-ctx.Done()
+	})
+	for i := 0; i < 10; i++ {
+		p = p.Then(func(n int) int { return n })
+	}
+	cancel()
+	p.Await()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(true, runtime.NumGoroutine() <= before)
+}
+
+func TestChain_OneWatcherPerContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChain_OneWatcherPerContext")
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewWithContext(ctx, func(ctx context.Context, resolve func(int), reject func(error)) {
+		<-ctx.Done()
+	})
+	promises := []*Promise[int]{p}
+	for i := 0; i < 50; i++ {
+		p = p.Then(func(n int) int { return n })
+		promises = append(promises, p)
+	}
+
+	// the runnable and the watcher of ctx, however long the chain is
+	assert.Equal(true, runtime.NumGoroutine() <= before+2)
+
+	cancel()
+	for _, p := range promises {
+		_, err := p.Await()
+		assert.Equal(context.Canceled, err)
+	}
+}
+
+func TestChain_AbandonedWithoutContext(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChain_AbandonedWithoutContext")
+
+	before := runtime.NumGoroutine()
+
+	// the promise is never settled, its chain must not park any goroutine
+	p := New(func(resolve func(int), reject func(error)) {})
+	for i := 0; i < 10; i++ {
+		p = p.Then(func(n int) int { return n })
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(true, runtime.NumGoroutine() <= before)
+}
+
+func TestAllSettled(t *testing.T) {
+	assert := internal.NewAssert(t, "TestAllSettled")
+
+	err := errors.New("error")
+	p := AllSettled([]*Promise[int]{Resolve(1), Reject[int](err), Delay(10*time.Millisecond, 3)})
+
+	results, e := p.Await()
+	assert.IsNil(e)
+	assert.Equal([]SettledResult[int]{{Value: 1}, {Err: err}, {Value: 3}}, results)
+
+	assert.IsNil(AllSettled([]*Promise[int]{}))
+}
+
+func TestFinally(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFinally")
+
+	called := 0
+	val, err := Resolve(1).Finally(func() { called++ }).Await()
+	assert.IsNil(err)
+	assert.Equal(1, val)
+
+	_, err = Finally(Reject[int](errors.New("error")), func() { called++ }).Await()
+	assert.Equal("error", err.Error())
+	assert.Equal(2, called)
+}
+
+func TestMapLimit(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMapLimit")
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	p := MapLimit([]int{1, 2, 3, 4, 5}, 2, func(n int) *Promise[int] {
+		return New(func(resolve func(int), reject func(error)) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			resolve(n * n)
+		})
+	})
+
+	results, err := p.Await()
+	assert.IsNil(err)
+	assert.Equal([]int{1, 4, 9, 16, 25}, results)
+	assert.Equal(2, maxRunning)
+
+	created := 0
+	p = MapLimit([]int{1, 2, 3, 4}, 1, func(n int) *Promise[int] {
+		created++
+		if n == 2 {
+			return Reject[int](errors.New("error"))
+		}
+		return Resolve(n)
+	})
+	_, err = p.Await()
+	assert.Equal("error", err.Error())
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(true, created <= 3)
+
+	results, err = MapLimit([]int{}, 2, func(n int) *Promise[int] { return Resolve(n) }).Await()
+	assert.IsNil(err)
+	assert.Equal([]int{}, results)
+}
+
+func TestSequence(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSequence")
+
+	order := []int{}
+	factory := func(n int) func() *Promise[int] {
+		return func() *Promise[int] {
+			order = append(order, n)
+			return Delay(time.Duration(3-n)*time.Millisecond, n)
+		}
+	}
+
+	results, err := Sequence([]func() *Promise[int]{factory(0), factory(1), factory(2)}).Await()
+	assert.IsNil(err)
+	assert.Equal([]int{0, 1, 2}, results)
+	assert.Equal([]int{0, 1, 2}, order)
+
+	_, err = Sequence([]func() *Promise[int]{
+		func() *Promise[int] { return Reject[int](errors.New("error")) },
+		func() *Promise[int] { panic("should not be called") },
+	}).Await()
+	assert.Equal("error", err.Error())
+}
+
+func TestDelay(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDelay")
+
+	start := time.Now()
+	val, err := Delay(20*time.Millisecond, "abc").Await()
+
+	assert.IsNil(err)
+	assert.Equal("abc", val)
+	assert.Equal(true, time.Since(start) >= 20*time.Millisecond)
+}
+
+func TestRetry(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRetry")
+
+	attempts := 0
+	factory := func() *Promise[int] {
+		attempts++
+		if attempts < 3 {
+			return Reject[int](errors.New("error"))
+		}
+		return Resolve(attempts)
+	}
+
+	val, err := Retry(factory, 5, time.Millisecond).Await()
+	assert.IsNil(err)
+	assert.Equal(3, val)
+
+	attempts = 0
+	_, err = Retry(func() *Promise[int] {
+		attempts++
+		return Reject[int](errors.New("always"))
+	}, 3, time.Millisecond).Await()
+	assert.Equal("always", err.Error())
+	assert.Equal(3, attempts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts = 0
+	p := Retry(func() *Promise[int] {
+		attempts++
+		return NewWithContext(ctx, func(ctx context.Context, resolve func(int), reject func(error)) {
+			reject(errors.New("error"))
+		})
+	}, 3, time.Hour)
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// the wait before the next retry is cancelled with the context
+	_, err = p.Await()
+	assert.Equal(context.Canceled, err)
+	assert.Equal(1, attempts)
+}
+
+func TestThenErr(t *testing.T) {
+	assert := internal.NewAssert(t, "TestThenErr")
+
+	parse := func(s string) (int, error) {
+		if s == "" {
+			return 0, errors.New("empty")
+		}
+		return len(s), nil
+	}
+
+	val, err := ThenErr(Resolve("abc"), parse).Await()
+	assert.IsNil(err)
+	assert.Equal(3, val)
+
+	_, err = ThenErr(Resolve(""), parse).Await()
+	assert.Equal("empty", err.Error())
+
+	_, err = ThenErr(Reject[string](errors.New("error")), parse).Await()
+	assert.Equal("error", err.Error())
+
+	val, err = Resolve(1).ThenErr(func(n int) (int, error) { return n + 1, nil }).Await()
+	assert.IsNil(err)
+	assert.Equal(2, val)
+}
+
+func TestRecover(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRecover")
+
+	val, err := Recover(Reject[int](errors.New("error")), func(err error) (int, error) {
+		return -1, nil
+	}).Await()
+	assert.IsNil(err)
+	assert.Equal(-1, val)
+
+	val, err = Resolve(1).Recover(func(err error) (int, error) {
+		return -1, nil
+	}).Await()
+	assert.IsNil(err)
+	assert.Equal(1, val)
+
+	_, err = Reject[int](errors.New("error")).Recover(func(err error) (int, error) {
+		return 0, errors.New("still failed")
+	}).Await()
+	assert.Equal("still failed", err.Error())
+}
+
+func TestPanicRejection(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPanicRejection")
+
+	p := New(func(resolve func(int), reject func(error)) {
+		panic("boom")
+	})
+
+	_, err := p.Await()
+	assert.Equal("boom", err.Error())
+
+	xerr := xerror.Unwrap(err)
+	assert.IsNotNil(xerr)
+	assert.Equal("boom", xerr.Values()["panic"])
+	assert.Equal(true, len(xerr.StackTrace()) > 0)
+
+	cause := errors.New("then panic")
+	_, err = Then(Resolve(1), func(n int) int {
+		panic(cause)
+	}).Await()
+	assert.Equal("panic: then panic", err.Error())
+	assert.IsNotNil(xerror.Unwrap(err))
+	assert.Equal(true, errors.Is(err, cause))
+}
@@ -26,6 +26,7 @@ type RetryConfig struct {
 	context       context.Context
 	retryTimes    uint
 	retryDuration time.Duration
+	stopOn        func(err error) bool
 }
 
 // RetryFunc is function that retry executes
@@ -58,6 +59,14 @@ func Context(ctx context.Context) Option {
 	}
 }
 
+// StopOn set the function deciding whether an error is permanent, Retry returns such an error at once without retrying.
+// e.g. stop on function.ErrBreakerOpen instead of retrying against an open circuit breaker.
+func StopOn(stop func(err error) bool) Option {
+	return func(rc *RetryConfig) {
+		rc.stopOn = stop
+	}
+}
+
 // Retry executes the retryFunc repeatedly until it was successful or canceled by the context
 // The default times of retries is 5 and the default duration between retries is 3 seconds.
 // Play: https://go.dev/play/p/nk2XRmagfVF
@@ -76,6 +85,10 @@ func Retry(retryFunc RetryFunc, opts ...Option) error {
 	for i < config.retryTimes {
 		err := retryFunc()
 		if err != nil {
+			if config.stopOn != nil && config.stopOn(err) {
+				return err
+			}
+
 			select {
 			case <-time.After(config.retryDuration):
 			case <-config.context.Done():
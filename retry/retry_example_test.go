@@ -91,3 +91,23 @@ func ExampleRetry() {
 	// Output:
 	// 3
 }
+
+func ExampleStopOn() {
+	errNotFound := errors.New("not found")
+
+	number := 0
+	find := func() error {
+		number++
+		return errNotFound
+	}
+
+	err := Retry(find,
+		RetryDuration(time.Microsecond*50),
+		StopOn(func(err error) bool { return errors.Is(err, errNotFound) }),
+	)
+
+	fmt.Println(number, err)
+
+	// Output:
+	// 1 not found
+}
@@ -78,3 +78,26 @@ func TestCancelRetry(t *testing.T) {
 	assert.IsNotNil(err)
 	assert.Equal(4, number)
 }
+
+func TestStopOn(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStopOn")
+
+	errPermanent := errors.New("permanent")
+
+	var number int
+	increaseNumber := func() error {
+		number++
+		if number == 2 {
+			return errPermanent
+		}
+		return errors.New("error occurs")
+	}
+
+	err := Retry(increaseNumber,
+		RetryDuration(time.Microsecond*50),
+		StopOn(func(err error) bool { return errors.Is(err, errPermanent) }),
+	)
+
+	assert.Equal(errPermanent, err)
+	assert.Equal(2, number)
+}
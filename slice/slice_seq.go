@@ -0,0 +1,90 @@
+//go:build go1.23
+
+package slice
+
+import "iter"
+
+// MapSeq is the lazy version of Map, it returns an iter.Seq which applies iteratee to every item of slice when ranged over.
+func MapSeq[T any, U any](slice []T, iteratee func(index int, item T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for i, v := range slice {
+			if !yield(iteratee(i, v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq is the lazy version of Filter, it returns an iter.Seq of the items which pass predicate.
+func FilterSeq[T any](slice []T, predicate func(index int, item T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, v := range slice {
+			if predicate(i, v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMapSeq is the lazy version of FilterMap.
+func FilterMapSeq[T any, U any](slice []T, iteratee func(index int, item T) (U, bool)) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for i, v := range slice {
+			if result, ok := iteratee(i, v); ok && !yield(result) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapSeq is the lazy version of FlatMap.
+func FlatMapSeq[T any, U any](slice []T, iteratee func(index int, item T) []U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for i, v := range slice {
+			for _, item := range iteratee(i, v) {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ChunkSeq is the lazy version of Chunk, the yielded chunks share the underlying array of slice.
+func ChunkSeq[T any](slice []T, size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+
+		for start := 0; start < len(slice); start += size {
+			end := start + size
+			if end > len(slice) {
+				end = len(slice)
+			}
+			if !yield(slice[start:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// ReverseSeq returns an iter.Seq2 of index and item of slice from the last item to the first, slice is not changed.
+func ReverseSeq[T any](slice []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(slice) - 1; i >= 0; i-- {
+			if !yield(i, slice[i]) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq collects the items of seq into a new slice.
+func FromSeq[T any](seq iter.Seq[T]) []T {
+	result := []T{}
+	for item := range seq {
+		result = append(result, item)
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package slice
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestSliceSeq(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSliceSeq")
+
+	nums := []int{1, 2, 3, 4, 5}
+	isEven := func(_ int, n int) bool { return n%2 == 0 }
+
+	assert.Equal([]int{2, 4}, FromSeq(FilterSeq(nums, isEven)))
+	assert.Equal([]int{0, 2, 6, 12, 20}, FromSeq(MapSeq(nums, func(i, n int) int { return i * n })))
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, FromSeq(ChunkSeq(nums, 2)))
+
+	result := []int{}
+	for i, n := range ReverseSeq(nums) {
+		if i < 3 {
+			break
+		}
+		result = append(result, n)
+	}
+	assert.Equal([]int{5, 4}, result)
+}
@@ -0,0 +1,164 @@
+// Package stream implements a sequence of elements supporting sequential and operations.
+// this package is an experiment to explore if stream in go can work as the way java does. it's function is very limited.
+package stream
+
+import (
+	"math"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Collector describes a mutable reduction of stream elements, like java.util.stream.Collector.
+// Supplier creates the accumulation container, Accumulator folds an element into it, Finisher converts it to the result.
+type Collector[T any, A any, R any] struct {
+	Supplier    func() A
+	Accumulator func(container A, item T) A
+	Finisher    func(container A) R
+}
+
+// Collect performs a mutable reduction on the elements of s using collector.
+func Collect[T any, A any, R any](s Stream[T], collector Collector[T, A, R]) R {
+	container := collector.Supplier()
+
+	s.each(func(item T) bool {
+		container = collector.Accumulator(container, item)
+		return true
+	})
+
+	return collector.Finisher(container)
+}
+
+// ToSlice returns a Collector that collects elements into a slice.
+func ToSlice[T any]() Collector[T, []T, []T] {
+	return Collector[T, []T, []T]{
+		Supplier: func() []T { return []T{} },
+		Accumulator: func(container []T, item T) []T {
+			return append(container, item)
+		},
+		Finisher: identity[[]T],
+	}
+}
+
+// ToMap returns a Collector that collects elements into a map, whose keys and values are the results of keyMapper and valueMapper.
+// if keys are duplicated, values are merged by the optional merge function, or the later one wins.
+func ToMap[T any, K comparable, V any](keyMapper func(item T) K, valueMapper func(item T) V, merge ...func(old, new V) V) Collector[T, map[K]V, map[K]V] {
+	return Collector[T, map[K]V, map[K]V]{
+		Supplier: func() map[K]V { return make(map[K]V) },
+		Accumulator: func(container map[K]V, item T) map[K]V {
+			key, value := keyMapper(item), valueMapper(item)
+			if old, ok := container[key]; ok && len(merge) > 0 {
+				value = merge[0](old, value)
+			}
+			container[key] = value
+			return container
+		},
+		Finisher: identity[map[K]V],
+	}
+}
+
+// GroupingBy returns a Collector that groups elements by classifier, then collects the elements of every group with downstream.
+func GroupingBy[T any, K comparable, A any, R any](classifier func(item T) K, downstream Collector[T, A, R]) Collector[T, map[K]A, map[K]R] {
+	return Collector[T, map[K]A, map[K]R]{
+		Supplier: func() map[K]A { return make(map[K]A) },
+		Accumulator: func(container map[K]A, item T) map[K]A {
+			key := classifier(item)
+			group, ok := container[key]
+			if !ok {
+				group = downstream.Supplier()
+			}
+			container[key] = downstream.Accumulator(group, item)
+			return container
+		},
+		Finisher: func(container map[K]A) map[K]R {
+			result := make(map[K]R, len(container))
+			for k, v := range container {
+				result[k] = downstream.Finisher(v)
+			}
+			return result
+		},
+	}
+}
+
+// PartitioningBy returns a Collector that partitions elements by predicate, then collects every partition with downstream.
+// the result always has both true and false keys.
+func PartitioningBy[T any, A any, R any](predicate func(item T) bool, downstream Collector[T, A, R]) Collector[T, map[bool]A, map[bool]R] {
+	grouping := GroupingBy(predicate, downstream)
+	grouping.Supplier = func() map[bool]A {
+		return map[bool]A{true: downstream.Supplier(), false: downstream.Supplier()}
+	}
+
+	return grouping
+}
+
+// Joining returns a Collector that concatenates string elements, separated by separator, surrounded by prefix and suffix.
+func Joining(separator, prefix, suffix string) Collector[string, []string, string] {
+	return Collector[string, []string, string]{
+		Supplier: func() []string { return []string{} },
+		Accumulator: func(container []string, item string) []string {
+			return append(container, item)
+		},
+		Finisher: func(container []string) string {
+			return prefix + strings.Join(container, separator) + suffix
+		},
+	}
+}
+
+// Counting returns a Collector that counts the number of elements.
+func Counting[T any]() Collector[T, int, int] {
+	return Collector[T, int, int]{
+		Supplier:    func() int { return 0 },
+		Accumulator: func(container int, _ T) int { return container + 1 },
+		Finisher:    identity[int],
+	}
+}
+
+// Summary holds the count, sum, min, max, average and population standard deviation of numbers,
+// it is the result of Summarizing and Statistics.
+type Summary[N constraints.Integer | constraints.Float] struct {
+	Count   int
+	Sum     N
+	Min     N
+	Max     N
+	Average float64
+	StdDev  float64
+
+	// m2 is the sum of squared differences from the running average, see Welford's online algorithm
+	m2 float64
+}
+
+// Summarizing returns a Collector that maps elements to numbers with mapper, and summarizes them.
+// Min, Max, Average and StdDev are zero values if there is no element.
+func Summarizing[T any, N constraints.Integer | constraints.Float](mapper func(item T) N) Collector[T, Summary[N], Summary[N]] {
+	return Collector[T, Summary[N], Summary[N]]{
+		Supplier: func() Summary[N] { return Summary[N]{} },
+		Accumulator: func(container Summary[N], item T) Summary[N] {
+			n := mapper(item)
+			if container.Count == 0 || n < container.Min {
+				container.Min = n
+			}
+			if container.Count == 0 || n > container.Max {
+				container.Max = n
+			}
+			container.Count++
+			container.Sum += n
+
+			delta := float64(n) - container.Average
+			container.Average += delta / float64(container.Count)
+			container.m2 += delta * (float64(n) - container.Average)
+
+			return container
+		},
+		Finisher: func(container Summary[N]) Summary[N] {
+			if container.Count > 0 {
+				container.StdDev = math.Sqrt(container.m2 / float64(container.Count))
+			}
+			container.m2 = 0
+			return container
+		},
+	}
+}
+
+func identity[T any](v T) T {
+	return v
+}
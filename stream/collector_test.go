@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+type person struct {
+	Name string
+	Age  int
+}
+
+var people = []person{
+	{Name: "Tom", Age: 20},
+	{Name: "Jim", Age: 30},
+	{Name: "Ann", Age: 20},
+}
+
+func TestMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap")
+
+	names := Map(FromSlice(people), func(p person) string { return p.Name })
+	assert.Equal([]string{"Tom", "Jim", "Ann"}, names.ToSlice())
+}
+
+func TestFlatMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFlatMap")
+
+	s := FlatMap(Of(1, 2), func(n int) Stream[string] {
+		return Of(strconv.Itoa(n), strconv.Itoa(n*10))
+	})
+	assert.Equal([]string{"1", "10", "2", "20"}, s.ToSlice())
+}
+
+func TestCollect(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCollect")
+
+	name := func(p person) string { return p.Name }
+	age := func(p person) int { return p.Age }
+
+	assert.Equal(people, Collect(FromSlice(people), ToSlice[person]()))
+
+	ages := Collect(FromSlice(people), ToMap(name, age))
+	assert.Equal(map[string]int{"Tom": 20, "Jim": 30, "Ann": 20}, ages)
+
+	sumByAge := Collect(FromSlice(people), ToMap(age, func(person) int { return 1 }, func(a, b int) int { return a + b }))
+	assert.Equal(map[int]int{20: 2, 30: 1}, sumByAge)
+
+	groups := Collect(FromSlice(people), GroupingBy(age, Counting[person]()))
+	assert.Equal(map[int]int{20: 2, 30: 1}, groups)
+
+	partitions := Collect(FromSlice(people), PartitioningBy(func(p person) bool { return p.Age > 25 }, ToSlice[person]()))
+	assert.Equal(map[bool][]person{true: {people[1]}, false: {people[0], people[2]}}, partitions)
+
+	empty := Collect(Of[person](), PartitioningBy(func(p person) bool { return p.Age > 25 }, Counting[person]()))
+	assert.Equal(map[bool]int{true: 0, false: 0}, empty)
+
+	joined := Collect(Map(FromSlice(people), name), Joining(", ", "[", "]"))
+	assert.Equal("[Tom, Jim, Ann]", joined)
+}
+
+func TestSummarizing(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSummarizing")
+
+	summary := Collect(FromSlice(people), Summarizing(func(p person) int { return p.Age }))
+	assert.Equal(3, summary.Count)
+	assert.Equal(70, summary.Sum)
+	assert.Equal(20, summary.Min)
+	assert.Equal(30, summary.Max)
+	assert.Equal(70.0/3, summary.Average)
+	assert.Equal("4.714", fmt.Sprintf("%.3f", summary.StdDev))
+
+	summary = Collect(Of[person](), Summarizing(func(p person) int { return p.Age }))
+	assert.Equal(Summary[int]{}, summary)
+
+	// the average does not depend on Sum, which overflows
+	small := Collect(Of[int8](100, 100, 100), Summarizing(func(n int8) int8 { return n }))
+	assert.Equal(100.0, small.Average)
+	assert.Equal(0.0, small.StdDev)
+}
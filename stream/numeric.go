@@ -0,0 +1,28 @@
+// Package stream implements a sequence of elements supporting sequential and operations.
+// this package is an experiment to explore if stream in go can work as the way java does. it's function is very limited.
+package stream
+
+import "golang.org/x/exp/constraints"
+
+// Sum returns the sum of the elements of a number stream.
+func Sum[T constraints.Integer | constraints.Float](s Stream[T]) T {
+	var sum T
+
+	s.each(func(item T) bool {
+		sum += item
+		return true
+	})
+
+	return sum
+}
+
+// Average returns the arithmetic mean of the elements of a number stream, and false if the stream is empty.
+func Average[T constraints.Integer | constraints.Float](s Stream[T]) (float64, bool) {
+	summary := Statistics(s)
+	return summary.Average, summary.Count > 0
+}
+
+// Statistics returns the count, sum, min, max, average and standard deviation of the elements of a number stream.
+func Statistics[T constraints.Integer | constraints.Float](s Stream[T]) Summary[T] {
+	return Collect(s, Summarizing(identity[T]))
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestSumAndAverage(t *testing.T) {
+	assert := internal.NewAssert(t, "TestSumAndAverage")
+
+	assert.Equal(10, Sum(Of(1, 2, 3, 4)))
+	assert.Equal(0.0, Sum(Of[float64]()))
+
+	avg, ok := Average(Of(1, 2, 3, 4))
+	assert.Equal(2.5, avg)
+	assert.Equal(true, ok)
+
+	_, ok = Average(Of[int]())
+	assert.Equal(false, ok)
+}
+
+func TestStatistics(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStatistics")
+
+	stats := Statistics(Of(2.0, 4.0, 4.0, 4.0, 5.0, 5.0, 7.0, 9.0))
+	assert.Equal(8, stats.Count)
+	assert.Equal(40.0, stats.Sum)
+	assert.Equal(2.0, stats.Min)
+	assert.Equal(9.0, stats.Max)
+	assert.Equal(5.0, stats.Average)
+	assert.Equal(2.0, stats.StdDev)
+}
+
+func TestZip(t *testing.T) {
+	assert := internal.NewAssert(t, "TestZip")
+
+	s := Zip(Of(1, 2, 3), Of("a", "b"), func(n int, s string) string {
+		return s + string(rune('0'+n))
+	})
+	assert.Equal([]string{"a1", "b2"}, s.ToSlice())
+}
+
+func TestChunkAndWindow(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChunkAndWindow")
+
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, Chunk(Of(1, 2, 3, 4, 5), 2).ToSlice())
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}}, Window(Of(1, 2, 3, 4), 3).ToSlice())
+}
+
+func TestStream_TakeWhileAndDropWhile(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_TakeWhileAndDropWhile")
+
+	lessThan3 := func(n int) bool { return n < 3 }
+
+	assert.Equal([]int{1, 2}, Of(1, 2, 3, 1).TakeWhile(lessThan3).ToSlice())
+	assert.Equal([]int{3, 1}, Of(1, 2, 3, 1).DropWhile(lessThan3).ToSlice())
+}
+
+func TestStream_IndexOfAndLast(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_IndexOfAndLast")
+
+	s := Of(1, 2, 3, 4)
+
+	assert.Equal(2, s.IndexOf(func(n int) bool { return n > 2 }))
+	assert.Equal(-1, s.IndexOf(func(n int) bool { return n > 4 }))
+
+	last, ok := s.Last()
+	assert.Equal(4, last)
+	assert.Equal(true, ok)
+
+	_, ok = Of[int]().Last()
+	assert.Equal(false, ok)
+}
+
+func TestIterate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIterate")
+
+	double := func(n int) int { return n * 2 }
+
+	assert.Equal([]int{1, 2, 4, 8}, Iterate(1, double).Limit(4).ToSlice())
+	assert.Equal([]int{1, 2, 4}, Iterate(1, double).TakeWhile(func(n int) bool { return n < 5 }).ToSlice())
+	assert.Equal([]int{1, 2, 4}, IterateWhile(1, func(n int) bool { return n < 5 }, double).ToSlice())
+}
@@ -0,0 +1,189 @@
+// Package stream implements a sequence of elements supporting sequential and operations.
+// this package is an experiment to explore if stream in go can work as the way java does. it's function is very limited.
+package stream
+
+import (
+	"io"
+	"sync"
+
+	"github.com/serialt/lancet/iterator"
+)
+
+// parallel returns a stream which applies fn to the elements of s with s.workers goroutines,
+// the results whose keep is false are dropped. At most 2*workers elements are in flight.
+// A panic in fn or in the stages before it is recovered in its goroutine, and raised again in the goroutine consuming the stream.
+func parallel[T any, U any](s Stream[T], fn func(item T) (result U, keep bool)) Stream[U] {
+	workers, unordered := s.workers, s.unordered
+
+	return pipe(s, func(done <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[U] {
+		if unordered {
+			return unorderedParallel(done, iter, workers, fn)
+		}
+		return orderedParallel(done, iter, workers, fn)
+	})
+}
+
+type parallelResult[U any] struct {
+	value U
+	keep  bool
+
+	panicked   bool
+	panicValue any
+}
+
+// get returns the value of result, or panics with the recovered panic value.
+func (r parallelResult[U]) get() (U, bool) {
+	if r.panicked {
+		panic(r.panicValue)
+	}
+	return r.value, r.keep
+}
+
+// call calls fn with item, a panic of fn is recovered into the result.
+func call[T any, U any](fn func(item T) (U, bool), item T) (result parallelResult[U]) {
+	defer func() {
+		if v := recover(); v != nil {
+			result = parallelResult[U]{panicked: true, panicValue: v}
+		}
+	}()
+
+	value, keep := fn(item)
+
+	return parallelResult[U]{value: value, keep: keep}
+}
+
+// produce calls send with every item of iter until it returns false, a panic of iter is recovered and returned.
+func produce[T any](iter iterator.Iterator[T], send func(item T) bool) (panicValue any) {
+	defer func() {
+		panicValue = recover()
+	}()
+
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if !send(item) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+type orderedJob[T any, U any] struct {
+	item   T
+	result chan parallelResult[U]
+}
+
+// orderedParallel keeps the order of elements by queueing a result channel for every element,
+// the consumer waits on them in order while workers fill them concurrently.
+func orderedParallel[T any, U any](done <-chan struct{}, iter iterator.Iterator[T], workers int, fn func(item T) (U, bool)) iterator.Iterator[U] {
+	jobs := make(chan orderedJob[T, U])
+	queue := make(chan chan parallelResult[U], workers)
+
+	go func() {
+		defer close(jobs)
+		defer close(queue)
+
+		panicValue := produce(iter, func(item T) bool {
+			job := orderedJob[T, U]{item: item, result: make(chan parallelResult[U], 1)}
+
+			select {
+			case queue <- job.result:
+			case <-done:
+				return false
+			}
+			select {
+			case jobs <- job:
+			case <-done:
+				return false
+			}
+			return true
+		})
+
+		if panicValue != nil {
+			result := make(chan parallelResult[U], 1)
+			result <- parallelResult[U]{panicked: true, panicValue: panicValue}
+			select {
+			case queue <- result:
+			case <-done:
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- call(fn, job.item)
+			}
+		}()
+	}
+
+	return iterator.FromFunc(func() (U, error) {
+		for result := range queue {
+			if value, keep := (<-result).get(); keep {
+				return value, nil
+			}
+		}
+
+		var zero U
+		return zero, io.EOF
+	})
+}
+
+// unorderedParallel emits results as soon as workers finish them.
+func unorderedParallel[T any, U any](done <-chan struct{}, iter iterator.Iterator[T], workers int, fn func(item T) (U, bool)) iterator.Iterator[U] {
+	jobs := make(chan T)
+	results := make(chan parallelResult[U], workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers + 1)
+
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+
+		panicValue := produce(iter, func(item T) bool {
+			select {
+			case jobs <- item:
+				return true
+			case <-done:
+				return false
+			}
+		})
+
+		if panicValue != nil {
+			select {
+			case results <- parallelResult[U]{panicked: true, panicValue: panicValue}:
+			case <-done:
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for item := range jobs {
+				select {
+				case results <- call(fn, item):
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return iterator.FromFunc(func() (U, error) {
+		for r := range results {
+			if value, keep := r.get(); keep {
+				return value, nil
+			}
+		}
+
+		var zero U
+		return zero, io.EOF
+	})
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serialt/lancet/internal"
+)
+
+func TestStream_Lazy(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_Lazy")
+
+	ch := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			ch <- i
+		}
+	}()
+
+	result := FromChannel(ch).Filter(func(n int) bool { return n%2 == 0 }).Limit(3).ToSlice()
+	assert.Equal([]int{0, 2, 4}, result)
+
+	pulled := 0
+	s := FromRange(1, 100, 1).Peek(func(int) { pulled++ })
+
+	assert.Equal(true, s.AnyMatch(func(n int) bool { return n == 3 }))
+	assert.Equal(3, pulled)
+
+	pulled = 0
+	first, _ := s.FindFirst()
+	assert.Equal(1, first)
+	assert.Equal(1, pulled)
+}
+
+func TestStream_Parallel(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_Parallel")
+
+	double := func(n int) int {
+		time.Sleep(time.Duration(10-n) * time.Millisecond)
+		return n * 2
+	}
+
+	s := FromRange(0, 9, 1).Parallel(4)
+	assert.Equal(true, s.IsParallel())
+	assert.Equal([]int{0, 4, 8, 12, 16}, s.Filter(func(n int) bool { return n%2 == 0 }).Map(double).ToSlice())
+
+	unordered := Map(s.Unordered(), double).ToSlice()
+	sort.Ints(unordered)
+	assert.Equal([]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, unordered)
+
+	var sum int64
+	s.ForEach(func(n int) { atomic.AddInt64(&sum, int64(n)) })
+	assert.Equal(int64(45), sum)
+
+	assert.Equal(false, s.Sequential().IsParallel())
+}
+
+func TestStream_ParallelShortCircuit(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_ParallelShortCircuit")
+
+	before := runtime.NumGoroutine()
+
+	for _, s := range []Stream[int]{FromRange(0, 1000, 1).Parallel(4), FromRange(0, 1000, 1).Parallel(4).Unordered()} {
+		result := s.Map(func(n int) int { return n }).Limit(2).Count()
+		assert.Equal(2, result)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(before, runtime.NumGoroutine())
+}
+
+func TestStream_ParallelPanic(t *testing.T) {
+	assert := internal.NewAssert(t, "TestStream_ParallelPanic")
+
+	recovered := func(fn func()) (value any) {
+		defer func() {
+			value = recover()
+		}()
+		fn()
+		return nil
+	}
+
+	boom := func(n int) int {
+		if n == 5 {
+			panic("boom")
+		}
+		return n
+	}
+
+	assert.Equal("boom", recovered(func() {
+		FromRange(1, 10, 1).Parallel(4).Map(boom).ToSlice()
+	}))
+	assert.Equal("boom", recovered(func() {
+		FromRange(1, 10, 1).Parallel(4).Unordered().Map(boom).ToSlice()
+	}))
+
+	// a panic of a sequential stage feeding the workers is raised in the consumer too
+	assert.Equal("boom", recovered(func() {
+		FromRange(1, 10, 1).Peek(func(n int) { boom(n) }).Parallel(4).Filter(func(int) bool { return true }).ToSlice()
+	}))
+	assert.Equal("boom", recovered(func() {
+		FromRange(1, 10, 1).Peek(func(n int) { boom(n) }).Parallel(4).Unordered().Filter(func(int) bool { return true }).ToSlice()
+	}))
+}
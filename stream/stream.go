@@ -8,7 +8,9 @@ package stream
 import (
 	"bytes"
 	"encoding/gob"
+	"io"
 
+	"github.com/serialt/lancet/iterator"
 	"github.com/serialt/lancet/slice"
 	"golang.org/x/exp/constraints"
 )
@@ -47,54 +49,61 @@ import (
 // 	Concat(streams ...StreamI[T]) StreamI[T]
 // }
 
-type stream[T any] struct {
-	source []T
+// Stream is a sequence of elements supporting sequential and parallel operations, create it with Of, FromSlice, FromChannel, etc.
+// Intermediate operations like Filter, Map and Limit are lazy, they only describe the pipeline.
+// Elements are pulled through the pipeline one by one when a terminal operation like ToSlice, ForEach or Count is called,
+// and short-circuiting operations like Limit, FindFirst and AnyMatch stop pulling as soon as the result is known.
+// Every terminal operation evaluates the pipeline from its source again.
+type Stream[T any] struct {
+	// iterate creates a new iterator over the elements of the stream,
+	// goroutines started by parallel stages exit when done is closed.
+	iterate func(done <-chan struct{}) iterator.Iterator[T]
+	// workers is the number of goroutines used by Map, Filter and ForEach, 0 or 1 means sequential.
+	workers   int
+	unordered bool
 }
 
 // Of creates a stream whose elements are the specified values.
 // Play: https://go.dev/play/p/jI6_iZZuVFE
-func Of[T any](elems ...T) stream[T] {
+func Of[T any](elems ...T) Stream[T] {
 	return FromSlice(elems)
 }
 
-// Generate stream where each element is generated by the provided generater function
+// Generate stream where each element is generated by the provided generater function.
+// generator is called once for every terminal operation, the stream ends when the returned function returns false.
 // Play: https://go.dev/play/p/rkOWL1yA3j9
-func Generate[T any](generator func() func() (item T, ok bool)) stream[T] {
-	source := make([]T, 0)
-
-	var zeroValue T
-	for next, item, ok := generator(), zeroValue, true; ok; {
-
-		item, ok = next()
-		if ok {
-			source = append(source, item)
-		}
-	}
-
-	return FromSlice(source)
+func Generate[T any](generator func() func() (item T, ok bool)) Stream[T] {
+	return fromIterator(func(_ <-chan struct{}) iterator.Iterator[T] {
+		next := generator()
+		return iterator.FromFunc(func() (T, error) {
+			item, ok := next()
+			if !ok {
+				return item, io.EOF
+			}
+			return item, nil
+		})
+	})
 }
 
 // FromSlice creates stream from slice.
 // Play: https://go.dev/play/p/wywTO0XZtI4
-func FromSlice[T any](source []T) stream[T] {
-	return stream[T]{source: source}
+func FromSlice[T any](source []T) Stream[T] {
+	return fromIterator(func(_ <-chan struct{}) iterator.Iterator[T] {
+		return iterator.FromSlice(source)
+	})
 }
 
-// FromChannel creates stream from channel.
+// FromChannel creates stream from channel. elements are received lazily, so it works with an infinite channel and Limit.
 // Play: https://go.dev/play/p/9TZYugGMhXZ
-func FromChannel[T any](source <-chan T) stream[T] {
-	s := make([]T, 0)
-
-	for v := range source {
-		s = append(s, v)
-	}
-
-	return FromSlice(s)
+func FromChannel[T any](source <-chan T) Stream[T] {
+	return fromIterator(func(_ <-chan struct{}) iterator.Iterator[T] {
+		return iterator.FromChannel(source)
+	})
 }
 
 // FromRange creates a number stream from start to end. both start and end are included. [start, end]
 // Play: https://go.dev/play/p/9Ex1-zcg-B-
-func FromRange[T constraints.Integer | constraints.Float](start, end, step T) stream[T] {
+func FromRange[T constraints.Integer | constraints.Float](start, end, step T) Stream[T] {
 	if end < start {
 		panic("stream.FromRange: param start should be before param end")
 	} else if step <= 0 {
@@ -102,46 +111,145 @@ func FromRange[T constraints.Integer | constraints.Float](start, end, step T) st
 	}
 
 	l := int((end-start)/step) + 1
-	source := make([]T, l)
 
-	for i := 0; i < l; i++ {
-		source[i] = start + (T(i) * step)
+	return fromIterator(func(_ <-chan struct{}) iterator.Iterator[T] {
+		i := 0
+		return iterator.FromFunc(func() (T, error) {
+			if i >= l {
+				return 0, io.EOF
+			}
+			i++
+			return start + (T(i-1) * step), nil
+		})
+	})
+}
+
+// Iterate creates an infinite stream of seed, next(seed), next(next(seed)) and so on, use Limit or TakeWhile to terminate it.
+func Iterate[T any](seed T, next func(item T) T) Stream[T] {
+	return IterateWhile(seed, func(T) bool { return true }, next)
+}
+
+// IterateWhile creates a stream of seed, next(seed), next(next(seed)) and so on, the stream ends at the first element that fails hasNext.
+func IterateWhile[T any](seed T, hasNext func(item T) bool, next func(item T) T) Stream[T] {
+	return fromIterator(func(_ <-chan struct{}) iterator.Iterator[T] {
+		item, started := seed, false
+		return iterator.FromFunc(func() (T, error) {
+			if started {
+				item = next(item)
+			}
+			started = true
+
+			if !hasNext(item) {
+				return item, io.EOF
+			}
+			return item, nil
+		})
+	})
+}
+
+func fromIterator[T any](iterate func(done <-chan struct{}) iterator.Iterator[T]) Stream[T] {
+	return Stream[T]{iterate: iterate}
+}
+
+// pipe returns a stream with the same parallel settings as s, whose iterator is built on the iterator of s by stage.
+func pipe[T any, U any](s Stream[T], stage func(done <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[U]) Stream[U] {
+	return Stream[U]{
+		iterate: func(done <-chan struct{}) iterator.Iterator[U] {
+			return stage(done, s.iterate(done))
+		},
+		workers:   s.workers,
+		unordered: s.unordered,
 	}
+}
+
+// each pulls elements of the stream and calls fn with them until fn returns false or the stream is exhausted.
+func (s Stream[T]) each(fn func(item T) bool) {
+	done := make(chan struct{})
+	defer close(done)
 
-	return FromSlice(source)
+	iter := s.iterate(done)
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		if !fn(item) {
+			return
+		}
+	}
 }
 
 // Concat creates a lazily concatenated stream whose elements are all the elements of the first stream followed by all the elements of the second stream.
 // Play: https://go.dev/play/p/HM4OlYk_OUC
-func Concat[T any](a, b stream[T]) stream[T] {
-	source := make([]T, 0)
-
-	source = append(source, a.source...)
-	source = append(source, b.source...)
+func Concat[T any](a, b Stream[T]) Stream[T] {
+	return pipe(a, func(done <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.Join(iter, b.iterate(done))
+	})
+}
 
-	return FromSlice(source)
+// Zip returns a stream whose elements are the results of applying zipper to the elements of a and b at the same position,
+// it ends when either a or b ends.
+func Zip[A any, B any, R any](a Stream[A], b Stream[B], zipper func(itemA A, itemB B) R) Stream[R] {
+	return pipe(a, func(done <-chan struct{}, iter iterator.Iterator[A]) iterator.Iterator[R] {
+		return iterator.Map(iterator.Zip(iter, b.iterate(done)), func(pair iterator.Pair[A, B]) R {
+			return zipper(pair.First, pair.Second)
+		})
+	})
 }
 
-// Distinct returns a stream that removes the duplicated items.
-// Play: https://go.dev/play/p/eGkOSrm64cB
-func (s stream[T]) Distinct() stream[T] {
-	source := make([]T, 0)
+// Chunk returns a stream of slices with size elements of s, the last chunk may be shorter.
+func Chunk[T any](s Stream[T], size int) Stream[[]T] {
+	if size <= 0 {
+		panic("stream.Chunk: param size should be positive")
+	}
 
-	distinct := map[string]bool{}
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[[]T] {
+		return iterator.Chunk(iter, size)
+	})
+}
 
-	for _, v := range s.source {
-		// todo: performance issue
-		k := hashKey(v)
-		if _, ok := distinct[k]; !ok {
-			distinct[k] = true
-			source = append(source, v)
-		}
+// Window returns a stream of sliding windows with size elements of s, every window starts one element after the previous one.
+func Window[T any](s Stream[T], size int) Stream[[]T] {
+	if size <= 0 {
+		panic("stream.Window: param size should be positive")
 	}
 
-	return FromSlice(source)
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[[]T] {
+		return iterator.Window(iter, size)
+	})
+}
+
+// Parallel returns a stream whose Map, Filter and ForEach operations run with the given number of goroutines.
+// The order of elements is preserved unless Unordered is called. workers less than 2 means sequential.
+func (s Stream[T]) Parallel(workers int) Stream[T] {
+	s.workers = workers
+	return s
+}
+
+// Unordered returns a stream whose parallel operations may emit elements in any order, which is faster for uneven workloads.
+func (s Stream[T]) Unordered() Stream[T] {
+	s.unordered = true
+	return s
+}
+
+// Sequential returns a stream whose operations run in the calling goroutine.
+func (s Stream[T]) Sequential() Stream[T] {
+	s.workers = 0
+	s.unordered = false
+	return s
+}
+
+// IsParallel checks if the Map, Filter and ForEach operations of the stream run concurrently or not.
+func (s Stream[T]) IsParallel() bool {
+	return s.workers > 1
+}
+
+// Distinct returns a stream that removes the duplicated items.
+// Play: https://go.dev/play/p/eGkOSrm64cB
+func (s Stream[T]) Distinct() Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		// todo: performance issue
+		return iterator.DistinctBy(iter, hashKey[T])
+	})
 }
 
-func hashKey(data any) string {
+func hashKey[T any](data T) string {
 	buffer := bytes.NewBuffer(nil)
 	encoder := gob.NewEncoder(buffer)
 	err := encoder.Encode(data)
@@ -153,163 +261,224 @@ func hashKey(data any) string {
 
 // Filter returns a stream consisting of the elements of this stream that match the given predicate.
 // Play: https://go.dev/play/p/MFlSANo-buc
-func (s stream[T]) Filter(predicate func(item T) bool) stream[T] {
-	source := make([]T, 0)
-
-	for _, v := range s.source {
-		if predicate(v) {
-			source = append(source, v)
-		}
+func (s Stream[T]) Filter(predicate func(item T) bool) Stream[T] {
+	if s.IsParallel() {
+		return parallel(s, func(item T) (T, bool) {
+			return item, predicate(item)
+		})
 	}
 
-	return FromSlice(source)
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.Filter(iter, predicate)
+	})
 }
 
 // Map returns a stream consisting of the elements of this stream that apply the given function to elements of stream.
 // Play: https://go.dev/play/p/OtNQUImdYko
-func (s stream[T]) Map(mapper func(item T) T) stream[T] {
-	source := make([]T, s.Count())
+func (s Stream[T]) Map(mapper func(item T) T) Stream[T] {
+	return Map(s, mapper)
+}
 
-	for i, v := range s.source {
-		source[i] = mapper(v)
+// Map returns a stream consisting of the results of applying mapper to the elements of s,
+// unlike method Map, the element type of returned stream can be different from s.
+func Map[T any, U any](s Stream[T], mapper func(item T) U) Stream[U] {
+	if s.IsParallel() {
+		return parallel(s, func(item T) (U, bool) {
+			return mapper(item), true
+		})
 	}
 
-	return FromSlice(source)
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[U] {
+		return iterator.Map(iter, mapper)
+	})
+}
+
+// FlatMap returns a stream consisting of the elements of the streams produced by applying mapper to the elements of s.
+func FlatMap[T any, U any](s Stream[T], mapper func(item T) Stream[U]) Stream[U] {
+	return pipe(s, func(done <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[U] {
+		return iterator.FlatMap(iter, func(item T) iterator.Iterator[U] {
+			return mapper(item).iterate(done)
+		})
+	})
 }
 
 // Peek returns a stream consisting of the elements of this stream, additionally performing the provided action on each element as elements are consumed from the resulting stream.
 // Play: https://go.dev/play/p/u1VNzHs6cb2
-func (s stream[T]) Peek(consumer func(item T)) stream[T] {
-	for _, v := range s.source {
-		consumer(v)
-	}
-
-	return s
+func (s Stream[T]) Peek(consumer func(item T)) Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.Map(iter, func(item T) T {
+			consumer(item)
+			return item
+		})
+	})
 }
 
 // Skip returns a stream consisting of the remaining elements of this stream after discarding the first n elements of the stream.
 // If this stream contains fewer than n elements then an empty stream will be returned.
 // Play: https://go.dev/play/p/fNdHbqjahum
-func (s stream[T]) Skip(n int) stream[T] {
+func (s Stream[T]) Skip(n int) Stream[T] {
 	if n <= 0 {
 		return s
 	}
 
-	source := make([]T, 0)
-	l := len(s.source)
-
-	if n > l {
-		return FromSlice(source)
-	}
-
-	for i := n; i < l; i++ {
-		source = append(source, s.source[i])
-	}
-
-	return FromSlice(source)
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.Skip(iter, n)
+	})
 }
 
 // Limit returns a stream consisting of the elements of this stream, truncated to be no longer than maxSize in length.
 // Play: https://go.dev/play/p/qsO4aniDcGf
-func (s stream[T]) Limit(maxSize int) stream[T] {
-	if s.source == nil {
-		return s
-	}
-
-	if maxSize < 0 {
-		return FromSlice([]T{})
-	}
-
-	source := make([]T, 0, maxSize)
+func (s Stream[T]) Limit(maxSize int) Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.Take(iter, maxSize)
+	})
+}
 
-	for i := 0; i < len(s.source) && i < maxSize; i++ {
-		source = append(source, s.source[i])
-	}
+// TakeWhile returns a stream consisting of the elements of this stream until the first element that fails predicate.
+func (s Stream[T]) TakeWhile(predicate func(item T) bool) Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.TakeWhile(iter, predicate)
+	})
+}
 
-	return FromSlice(source)
+// DropWhile returns a stream consisting of the elements of this stream from the first element that fails predicate.
+func (s Stream[T]) DropWhile(predicate func(item T) bool) Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		return iterator.DropWhile(iter, predicate)
+	})
 }
 
 // AllMatch returns whether all elements of this stream match the provided predicate.
 // Play: https://go.dev/play/p/V5TBpVRs-Cx
-func (s stream[T]) AllMatch(predicate func(item T) bool) bool {
-	for _, v := range s.source {
-		if !predicate(v) {
-			return false
-		}
-	}
+func (s Stream[T]) AllMatch(predicate func(item T) bool) bool {
+	result := true
+
+	s.each(func(item T) bool {
+		result = predicate(item)
+		return result
+	})
 
-	return true
+	return result
 }
 
 // AnyMatch returns whether any elements of this stream match the provided predicate.
 // Play: https://go.dev/play/p/PTCnWn4OxSn
-func (s stream[T]) AnyMatch(predicate func(item T) bool) bool {
-	for _, v := range s.source {
-		if predicate(v) {
-			return true
-		}
-	}
+func (s Stream[T]) AnyMatch(predicate func(item T) bool) bool {
+	result := false
 
-	return false
+	s.each(func(item T) bool {
+		result = predicate(item)
+		return !result
+	})
+
+	return result
 }
 
 // NoneMatch returns whether no elements of this stream match the provided predicate.
 // Play: https://go.dev/play/p/iWS64pL1oo3
-func (s stream[T]) NoneMatch(predicate func(item T) bool) bool {
+func (s Stream[T]) NoneMatch(predicate func(item T) bool) bool {
 	return !s.AnyMatch(predicate)
 }
 
 // ForEach performs an action for each element of this stream.
+// For a parallel stream, action is called concurrently and in no particular order.
 // Play: https://go.dev/play/p/Dsm0fPqcidk
-func (s stream[T]) ForEach(action func(item T)) {
-	for _, v := range s.source {
-		action(v)
+func (s Stream[T]) ForEach(action func(item T)) {
+	if s.IsParallel() {
+		s = parallel(s.Unordered(), func(item T) (T, bool) {
+			action(item)
+			return item, false
+		})
+		s.each(func(_ T) bool { return true })
+		return
 	}
+
+	s.each(func(item T) bool {
+		action(item)
+		return true
+	})
 }
 
 // Reduce performs a reduction on the elements of this stream, using an associative accumulation function, and returns an Optional describing the reduced value, if any.
 // Play: https://go.dev/play/p/6uzZjq_DJLU
-func (s stream[T]) Reduce(initial T, accumulator func(a, b T) T) T {
-	for _, v := range s.source {
-		initial = accumulator(initial, v)
-	}
+func (s Stream[T]) Reduce(initial T, accumulator func(a, b T) T) T {
+	s.each(func(item T) bool {
+		initial = accumulator(initial, item)
+		return true
+	})
 
 	return initial
 }
 
 // Count returns the count of elements in the stream.
 // Play: https://go.dev/play/p/r3koY6y_Xo-
-func (s stream[T]) Count() int {
-	return len(s.source)
+func (s Stream[T]) Count() int {
+	count := 0
+
+	s.each(func(_ T) bool {
+		count++
+		return true
+	})
+
+	return count
 }
 
 // FindFirst returns the first element of this stream and true, or zero value and false if the stream is empty.
 // Play: https://go.dev/play/p/9xEf0-6C1e3
-func (s stream[T]) FindFirst() (T, bool) {
+func (s Stream[T]) FindFirst() (T, bool) {
 	var result T
+	found := false
 
-	if s.source == nil || len(s.source) == 0 {
-		return result, false
-	}
+	s.each(func(item T) bool {
+		result, found = item, true
+		return false
+	})
 
-	return s.source[0], true
+	return result, found
+}
+
+// Last returns the last element of this stream and true, or zero value and false if the stream is empty.
+func (s Stream[T]) Last() (T, bool) {
+	var result T
+	found := false
+
+	s.each(func(item T) bool {
+		result, found = item, true
+		return true
+	})
+
+	return result, found
+}
+
+// IndexOf returns the index of the first element that matches predicate, or -1 if there is no such element.
+func (s Stream[T]) IndexOf(predicate func(item T) bool) int {
+	index, result := 0, -1
+
+	s.each(func(item T) bool {
+		if predicate(item) {
+			result = index
+			return false
+		}
+		index++
+		return true
+	})
+
+	return result
 }
 
 // Reverse returns a stream whose elements are reverse order of given stream.
 // Play: https://go.dev/play/p/A8_zkJnLHm4
-func (s stream[T]) Reverse() stream[T] {
-	l := len(s.source)
-	source := make([]T, l)
-
-	for i := 0; i < l; i++ {
-		source[i] = s.source[l-1-i]
-	}
-	return FromSlice(source)
+func (s Stream[T]) Reverse() Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		source := iterator.ToSlice(iter)
+		slice.Reverse(source)
+		return iterator.FromSlice(source)
+	})
 }
 
 // Range returns a stream whose elements are in the range from start(included) to end(excluded) original stream.
 // Play: https://go.dev/play/p/indZY5V2f4j
-func (s stream[T]) Range(start, end int) stream[T] {
+func (s Stream[T]) Range(start, end int) Stream[T] {
 	if start < 0 {
 		start = 0
 	}
@@ -317,72 +486,69 @@ func (s stream[T]) Range(start, end int) stream[T] {
 		end = 0
 	}
 	if start >= end {
-		return FromSlice([]T{})
-	}
-
-	source := make([]T, 0)
-
-	if end > len(s.source) {
-		end = len(s.source)
+		empty := FromSlice([]T{})
+		empty.workers, empty.unordered = s.workers, s.unordered
+		return empty
 	}
 
-	for i := start; i < end; i++ {
-		source = append(source, s.source[i])
-	}
-
-	return FromSlice(source)
+	return s.Skip(start).Limit(end - start)
 }
 
 // Sorted returns a stream consisting of the elements of this stream, sorted according to the provided less function.
 // Play: https://go.dev/play/p/XXtng5uonFj
-func (s stream[T]) Sorted(less func(a, b T) bool) stream[T] {
-	source := []T{}
-	source = append(source, s.source...)
-
-	slice.SortBy(source, less)
-
-	return FromSlice(source)
+func (s Stream[T]) Sorted(less func(a, b T) bool) Stream[T] {
+	return pipe(s, func(_ <-chan struct{}, iter iterator.Iterator[T]) iterator.Iterator[T] {
+		source := iterator.ToSlice(iter)
+		slice.SortBy(source, less)
+		return iterator.FromSlice(source)
+	})
 }
 
 // Max returns the maximum element of this stream according to the provided less function.
 // less: a > b
 // Play: https://go.dev/play/p/fm-1KOPtGzn
-func (s stream[T]) Max(less func(a, b T) bool) (T, bool) {
+func (s Stream[T]) Max(less func(a, b T) bool) (T, bool) {
 	var max T
+	found := false
 
-	if len(s.source) == 0 {
-		return max, false
-	}
-
-	for i, v := range s.source {
-		if less(v, max) || i == 0 {
-			max = v
+	s.each(func(item T) bool {
+		if !found || less(item, max) {
+			max = item
 		}
-	}
-	return max, true
+		found = true
+		return true
+	})
+
+	return max, found
 }
 
 // Min returns the minimum element of this stream according to the provided less function.
 // less: a < b
 // Play: https://go.dev/play/p/vZfIDgGNRe_0
-func (s stream[T]) Min(less func(a, b T) bool) (T, bool) {
+func (s Stream[T]) Min(less func(a, b T) bool) (T, bool) {
 	var min T
+	found := false
 
-	if len(s.source) == 0 {
-		return min, false
-	}
-
-	for i, v := range s.source {
-		if less(v, min) || i == 0 {
-			min = v
+	s.each(func(item T) bool {
+		if !found || less(item, min) {
+			min = item
 		}
-	}
+		found = true
+		return true
+	})
 
-	return min, true
+	return min, found
 }
 
 // ToSlice return the elements in the stream.
 // Play: https://go.dev/play/p/jI6_iZZuVFE
-func (s stream[T]) ToSlice() []T {
-	return s.source
+func (s Stream[T]) ToSlice() []T {
+	result := []T{}
+
+	s.each(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+
+	return result
 }
@@ -371,3 +371,38 @@ func ExampleStream_Count() {
 	// 3
 	// 0
 }
+
+func ExampleMap() {
+	s := Map(Of(1, 2, 3), func(n int) string {
+		return fmt.Sprintf("#%d", n)
+	})
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [#1 #2 #3]
+}
+
+func ExampleFlatMap() {
+	s := FlatMap(Of(1, 2), func(n int) Stream[int] {
+		return Of(n, n*10)
+	})
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [1 10 2 20]
+}
+
+func ExampleCollect() {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	counts := Collect(Of(1, 2, 3, 4, 5), PartitioningBy(isEven, Counting[int]()))
+
+	fmt.Println(counts[true])
+	fmt.Println(counts[false])
+
+	// Output:
+	// 2
+	// 3
+}
@@ -305,6 +305,10 @@ func TestStream_Range(t *testing.T) {
 
 	s6 := s.Range(0, 4)
 	assert.Equal([]int{1, 2, 3}, s6.ToSlice())
+
+	parallel := s.Parallel(4).Unordered().Range(2, 1)
+	assert.Equal(true, parallel.IsParallel())
+	assert.Equal([]int{}, parallel.ToSlice())
 }
 
 func TestStream_Concat(t *testing.T) {